@@ -2,25 +2,41 @@ package main
 
 import (
 	"context"
-	"log"
+	"errors"
+	"log/slog"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"argo_mcp/internal/server"
 )
 
 func main() {
-	// Set up logging
-	log.SetOutput(os.Stderr)
-	log.SetFlags(log.LstdFlags | log.Lshortfile)
+	// Cancel the context on SIGINT/SIGTERM so in-flight requests and ArgoCD
+	// calls are aborted cleanly instead of the process dying mid-request
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	// Create context
-	ctx := context.Background()
-
-	// Create and start the MCP server
-	mcpServer := server.NewMCPServer()
+	// Create and start the MCP server. NewMCPServer sets up leveled logging
+	// on stderr from LOG_LEVEL.
+	mcpServer, err := server.NewMCPServer()
+	if err != nil {
+		slog.Error("Failed to create server", "error", err)
+		os.Exit(1)
+	}
 
-	log.Println("Starting MCP server...")
-	if err := mcpServer.Run(ctx); err != nil {
-		log.Fatalf("Server failed: %v", err)
+	slog.Info("Starting MCP server...")
+	err = mcpServer.Run(ctx)
+	if ctx.Err() != nil {
+		slog.Info("Shutdown signal received, server stopped")
+		if err != nil && !errors.Is(err, context.Canceled) {
+			slog.Error("Error during shutdown", "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+	if err != nil {
+		slog.Error("Server failed", "error", err)
+		os.Exit(1)
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,122 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// UserInfo is the identity ArgoCD associates with the configured token
+type UserInfo struct {
+	LoggedIn bool     `json:"loggedIn"`
+	Username string   `json:"username,omitempty"`
+	Issuer   string   `json:"iss,omitempty"`
+	Groups   []string `json:"groups,omitempty"`
+}
+
+// AccessibleApplicationsArgs are the arguments for the list_accessible_applications tool
+type AccessibleApplicationsArgs struct {
+	Action string `json:"action,omitempty" jsonschema:"the RBAC action to check, e.g. get, sync, update, delete (default get)"`
+}
+
+// AccessibleApplicationsReport is the result of the list_accessible_applications tool
+type AccessibleApplicationsReport struct {
+	Identity  UserInfo          `json:"identity"`
+	Action    string            `json:"action"`
+	Permitted []string          `json:"permitted"`
+	Denied    []string          `json:"denied"`
+	Errors    map[string]string `json:"errors,omitempty"`
+	Note      string            `json:"note"`
+}
+
+// getUserInfo returns the identity of the configured token
+func (s *MCPServer) getUserInfo(ctx context.Context) (*UserInfo, error) {
+	var info UserInfo
+	if err := s.argocdGet(ctx, "/api/v1/session/userinfo", nil, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// canI asks ArgoCD whether the configured identity may perform action on
+// resource. ArgoCD RBAC addresses applications as <project>/<name>.
+func (s *MCPServer) canI(ctx context.Context, resource, action, subresource string) (bool, error) {
+	var answer struct {
+		Value string `json:"value"`
+	}
+	path := "/api/v1/account/can-i/" + url.PathEscape(resource) + "/" + url.PathEscape(action) + "/" + url.PathEscape(subresource)
+	if err := s.argocdGet(ctx, path, nil, &answer); err != nil {
+		return false, err
+	}
+	return answer.Value == "yes", nil
+}
+
+func (s *MCPServer) handleListAccessibleApplications(ctx context.Context, req *mcp.CallToolRequest, args AccessibleApplicationsArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	action := args.Action
+	if action == "" {
+		action = "get"
+	}
+
+	info, err := s.getUserInfo(ctx)
+	if err != nil {
+		return toolError(err)
+	}
+
+	// ArgoCD only lists the applications the caller may get, so the list
+	// itself already answers the "get" question
+	apps, err := s.getArgocdApplications(ctx, ApplicationFilter{})
+	if err != nil {
+		return toolError(err)
+	}
+
+	report := &AccessibleApplicationsReport{
+		Identity:  *info,
+		Action:    action,
+		Permitted: []string{},
+		Denied:    []string{},
+		Note:      "Permissions are evaluated for the identity of the configured token; ArgoCD's API cannot check on behalf of another user or group. Applications the identity cannot get are not listed by ArgoCD at all.",
+	}
+
+	if action == "get" {
+		for _, app := range apps.Items {
+			report.Permitted = append(report.Permitted, app.Metadata.Name)
+		}
+		sort.Strings(report.Permitted)
+		return toolJSON(report)
+	}
+
+	allowed := make([]bool, len(apps.Items))
+	errs := make([]error, len(apps.Items))
+	forEachConcurrent(ctx, len(apps.Items), func(ctx context.Context, i int) {
+		app := &apps.Items[i]
+		allowed[i], errs[i] = s.canI(ctx, "applications", action, app.Spec.Project+"/"+app.Metadata.Name)
+	})
+
+	for i, app := range apps.Items {
+		name := app.Metadata.Name
+		switch {
+		case errs[i] != nil:
+			if report.Errors == nil {
+				report.Errors = make(map[string]string)
+			}
+			report.Errors[name] = errs[i].Error()
+		case allowed[i]:
+			report.Permitted = append(report.Permitted, name)
+		default:
+			report.Denied = append(report.Denied, name)
+		}
+	}
+	if ctx.Err() != nil {
+		return toolError(fmt.Errorf("permission check interrupted: %w", ctx.Err()))
+	}
+
+	sort.Strings(report.Permitted)
+	sort.Strings(report.Denied)
+
+	return toolJSON(report)
+}
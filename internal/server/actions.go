@@ -0,0 +1,61 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// RunResourceActionArgs are the arguments for the run_resource_action tool
+type RunResourceActionArgs struct {
+	Name         string `json:"name" jsonschema:"the application name"`
+	Group        string `json:"group,omitempty" jsonschema:"the resource's API group, e.g. apps; empty for core resources"`
+	Kind         string `json:"kind" jsonschema:"the resource kind, e.g. Deployment"`
+	Namespace    string `json:"namespace,omitempty" jsonschema:"the resource namespace; empty for cluster-scoped resources"`
+	ResourceName string `json:"resource_name" jsonschema:"the resource name"`
+	Action       string `json:"action" jsonschema:"the action to run, e.g. restart, pause or resume"`
+}
+
+// resourceLabel names a resource for messages, e.g. "Deployment default/web"
+func resourceLabel(kind, namespace, name string) string {
+	if namespace == "" {
+		return kind + " " + name
+	}
+	return kind + " " + namespace + "/" + name
+}
+
+func (s *MCPServer) handleRunResourceAction(ctx context.Context, req *mcp.CallToolRequest, args RunResourceActionArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	if args.Name == "" {
+		return toolError(fmt.Errorf("name is required"))
+	}
+	if args.Kind == "" {
+		return toolError(fmt.Errorf("kind is required"))
+	}
+	if args.ResourceName == "" {
+		return toolError(fmt.Errorf("resource_name is required"))
+	}
+	if args.Action == "" {
+		return toolError(fmt.Errorf("action is required"))
+	}
+
+	// ArgoCD locates the resource in the application's tree, so the version
+	// isn't needed; the body is the bare action name as a JSON string
+	query := url.Values{}
+	query.Set("group", args.Group)
+	query.Set("kind", args.Kind)
+	query.Set("namespace", args.Namespace)
+	query.Set("resourceName", args.ResourceName)
+	if err := s.argocdDo(ctx, "POST", applicationPath(args.Name)+"/resource/actions", query, args.Action, nil); err != nil {
+		return toolError(err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: fmt.Sprintf("Action %q ran on %s of application %q", args.Action, resourceLabel(args.Kind, args.Namespace, args.ResourceName), args.Name)},
+		},
+	}, nil, nil
+}
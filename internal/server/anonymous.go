@@ -0,0 +1,76 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// anonymousProbeTimeout bounds the startup check for anonymous access
+const anonymousProbeTimeout = 10 * time.Second
+
+// writeTools are the tools that change state in ArgoCD. They are removed
+// when the server runs without credentials in anonymous read-only mode.
+var writeTools = []string{
+	"annotate_deploy",
+	"create_application",
+	"create_project",
+	"delete_application",
+	"delete_application_resource",
+	"patch_application",
+	"resync_application",
+	"rollback_application",
+	"run_resource_action",
+	"sync_and_report",
+	"sync_applications",
+	"terminate_operation",
+}
+
+// detectAnonymousMode checks whether an ArgoCD instance allows anonymous
+// access. It's only meaningful when no credentials are configured.
+func (s *MCPServer) detectAnonymousMode(ctx context.Context) (bool, error) {
+	// The settings endpoint is public, so it tells us ArgoCD is reachable
+	// before we read anything into an authorization failure
+	if err := s.argocdGet(ctx, "/api/v1/settings", nil, nil); err != nil {
+		return false, fmt.Errorf("failed to reach ArgoCD settings: %w", err)
+	}
+
+	// can-i answers for the anonymous role when users.anonymous.enabled is
+	// set, and rejects the request as unauthenticated otherwise
+	_, err := s.canI(ctx, "applications", "get", "*/*")
+	if errors.Is(err, ErrUnauthorized) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// enableAnonymousMode switches the server to anonymous read-only operation
+// when no credentials are configured and ArgoCD permits anonymous access
+func (s *MCPServer) enableAnonymousMode(ctx context.Context) {
+	if s.primary.authToken() != "" || s.primary.canLogin() {
+		return
+	}
+
+	// Don't hold up startup for long on an unreachable server
+	ctx, cancel := context.WithTimeout(ctx, anonymousProbeTimeout)
+	defer cancel()
+
+	anonymous, err := s.detectAnonymousMode(ctx)
+	if err != nil {
+		slog.Warn("No ArgoCD credentials configured and anonymous access could not be checked", "error", err)
+		return
+	}
+	if !anonymous {
+		slog.Warn("No ArgoCD credentials configured and ArgoCD does not allow anonymous access; set ARGOCD_AUTH_TOKEN")
+		return
+	}
+
+	s.anonymous = true
+	s.server.RemoveTools(writeTools...)
+	slog.Info("Running in anonymous read-only mode: no credentials configured, write tools disabled")
+}
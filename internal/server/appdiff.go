@@ -0,0 +1,169 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ApplicationDiffArgs are the arguments for the get_application_diff tool
+type ApplicationDiffArgs struct {
+	Name          string `json:"name" jsonschema:"the application name"`
+	OutOfSyncOnly bool   `json:"out_of_sync_only,omitempty" jsonschema:"only list resources whose live state differs from the desired state"`
+}
+
+// managedResource is one entry of ArgoCD's managed-resources response. The
+// states are JSON documents encoded as strings, "null" when absent.
+type managedResource struct {
+	Group               string `json:"group"`
+	Kind                string `json:"kind"`
+	Namespace           string `json:"namespace"`
+	Name                string `json:"name"`
+	TargetState         string `json:"targetState"`
+	LiveState           string `json:"liveState"`
+	NormalizedLiveState string `json:"normalizedLiveState"`
+	PredictedLiveState  string `json:"predictedLiveState"`
+	Hook                bool   `json:"hook"`
+	Modified            bool   `json:"modified"`
+}
+
+// ResourceStateDiff is the live-vs-desired comparison of one managed resource
+type ResourceStateDiff struct {
+	Group     string `json:"group,omitempty"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	// Status is Synced, OutOfSync, Missing (not in the cluster yet) or Extra
+	// (in the cluster but no longer desired)
+	Status string `json:"status"`
+	Hook   bool   `json:"hook,omitempty"`
+	Diff   string `json:"diff,omitempty"`
+}
+
+// ApplicationDiff is the result of the get_application_diff tool
+type ApplicationDiff struct {
+	Application    string              `json:"application"`
+	OutOfSyncCount int                 `json:"out_of_sync_count"`
+	SyncedCount    int                 `json:"synced_count"`
+	Resources      []ResourceStateDiff `json:"resources"`
+}
+
+// decodeState parses one of the string-encoded states of a managed resource,
+// returning nil for an absent state
+func decodeState(state string) (map[string]any, error) {
+	if state == "" || state == "null" {
+		return nil, nil
+	}
+	var obj map[string]any
+	if err := json.Unmarshal([]byte(state), &obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// diffManagedResource compares a managed resource's live and desired states
+func diffManagedResource(res *managedResource) (ResourceStateDiff, error) {
+	result := ResourceStateDiff{
+		Group:     res.Group,
+		Kind:      res.Kind,
+		Namespace: res.Namespace,
+		Name:      res.Name,
+		Hook:      res.Hook,
+		Status:    "Synced",
+	}
+
+	target, err := decodeState(res.TargetState)
+	if err != nil {
+		return result, fmt.Errorf("failed to decode target state of %s/%s: %w", res.Kind, res.Name, err)
+	}
+	// The normalized and predicted states have ignoreDifferences applied, so
+	// prefer them the way the argocd CLI does
+	live, err := decodeState(firstNonEmpty(res.NormalizedLiveState, res.LiveState))
+	if err != nil {
+		return result, fmt.Errorf("failed to decode live state of %s/%s: %w", res.Kind, res.Name, err)
+	}
+	desired := target
+	if predicted, err := decodeState(res.PredictedLiveState); err == nil && predicted != nil {
+		desired = predicted
+	}
+
+	switch {
+	case live == nil && target == nil:
+		return result, nil
+	case live == nil:
+		result.Status = "Missing"
+	case target == nil:
+		result.Status = "Extra"
+	case !res.Modified:
+		return result, nil
+	default:
+		result.Status = "OutOfSync"
+	}
+
+	liveText, desiredText := "", ""
+	if live != nil {
+		data, _ := json.MarshalIndent(live, "", "  ")
+		liveText = string(data)
+	}
+	if desired != nil {
+		data, _ := json.MarshalIndent(desired, "", "  ")
+		desiredText = string(data)
+	}
+	result.Diff = unifiedDiff("live", "desired", liveText, desiredText)
+	return result, nil
+}
+
+func (s *MCPServer) handleGetApplicationDiff(ctx context.Context, req *mcp.CallToolRequest, args ApplicationDiffArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	if args.Name == "" {
+		return toolError(fmt.Errorf("name is required"))
+	}
+
+	var managed struct {
+		Items []managedResource `json:"items"`
+	}
+	if err := s.argocdGet(ctx, applicationPath(args.Name)+"/managed-resources", nil, &managed); err != nil {
+		return toolError(err)
+	}
+
+	result := &ApplicationDiff{
+		Application: args.Name,
+		Resources:   []ResourceStateDiff{},
+	}
+	for i := range managed.Items {
+		diff, err := diffManagedResource(&managed.Items[i])
+		if err != nil {
+			return toolError(err)
+		}
+		if diff.Status == "Synced" {
+			result.SyncedCount++
+			if args.OutOfSyncOnly {
+				continue
+			}
+		} else {
+			result.OutOfSyncCount++
+		}
+		result.Resources = append(result.Resources, diff)
+	}
+
+	// Differing resources first, then by kind, namespace and name
+	sort.SliceStable(result.Resources, func(i, j int) bool {
+		a, b := result.Resources[i], result.Resources[j]
+		if (a.Status == "Synced") != (b.Status == "Synced") {
+			return a.Status != "Synced"
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+
+	return toolJSON(result)
+}
@@ -0,0 +1,358 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Views supported by the applications resource
+const (
+	applicationsViewFull    = "full"
+	applicationsViewSummary = "summary"
+	applicationsViewNames   = "names"
+)
+
+// ApplicationSummary is a compact view of an application
+type ApplicationSummary struct {
+	Name                 string `json:"name"`
+	Project              string `json:"project"`
+	SyncStatus           string `json:"sync_status"`
+	HealthStatus         string `json:"health_status"`
+	RepoURL              string `json:"repo_url"`
+	Path                 string `json:"path,omitempty"`
+	TargetRevision       string `json:"target_revision,omitempty"`
+	DestinationServer    string `json:"destination_server,omitempty"`
+	DestinationNamespace string `json:"destination_namespace,omitempty"`
+}
+
+// ApplicationNameArgs are the arguments for tools that operate on a single application
+type ApplicationNameArgs struct {
+	Name string `json:"name" jsonschema:"the application name"`
+}
+
+// ListApplicationsArgs are the arguments for the list_applications tool
+type ListApplicationsArgs struct {
+	Project  string `json:"project,omitempty" jsonschema:"only applications in this project"`
+	Selector string `json:"selector,omitempty" jsonschema:"Kubernetes label selector, e.g. team=payments,env!=dev"`
+	Repo     string `json:"repo,omitempty" jsonschema:"only applications sourced from this repository URL"`
+	Cluster  string `json:"cluster,omitempty" jsonschema:"only applications deploying to this destination server URL or cluster name"`
+
+	HealthStatus []string `json:"health_status,omitempty" jsonschema:"only applications with one of these health statuses, e.g. Degraded or Progressing (case-insensitive)"`
+	SyncStatus   []string `json:"sync_status,omitempty" jsonschema:"only applications with one of these sync statuses, e.g. OutOfSync (case-insensitive)"`
+}
+
+// ApplicationStatus is the compact sync and health state of an application
+type ApplicationStatus struct {
+	Name         string `json:"name"`
+	SyncStatus   string `json:"sync_status"`
+	HealthStatus string `json:"health_status"`
+	Revision     string `json:"revision,omitempty"`
+}
+
+// GetApplicationArgs are the arguments for the get_application tool
+type GetApplicationArgs struct {
+	Name      string `json:"name" jsonschema:"the application name"`
+	Namespace string `json:"namespace,omitempty" jsonschema:"the namespace the Application resource lives in, for apps outside ArgoCD's namespace (default ARGOCD_DEFAULT_APP_NAMESPACE)"`
+	Project   string `json:"project,omitempty" jsonschema:"only return the application if it belongs to this project"`
+}
+
+// ApplicationMetadata holds the object metadata of an application
+type ApplicationMetadata struct {
+	Name              string            `json:"name"`
+	Namespace         string            `json:"namespace"`
+	CreationTimestamp string            `json:"creation_timestamp,omitempty"`
+	DeletionTimestamp string            `json:"deletion_timestamp,omitempty"`
+	Finalizers        []string          `json:"finalizers"`
+	Labels            map[string]string `json:"labels"`
+	Annotations       map[string]string `json:"annotations"`
+}
+
+// ApplicationURLs holds the status summary of a deployed application
+type ApplicationURLs struct {
+	Name         string   `json:"name"`
+	ExternalURLs []string `json:"external_urls"`
+	Images       []string `json:"images"`
+}
+
+// sources returns the sources of an application: Spec.Sources for
+// multi-source applications, and Spec.Source otherwise
+func (app *ArgocdApplication) sources() []ApplicationSource {
+	if len(app.Spec.Sources) > 0 {
+		return app.Spec.Sources
+	}
+	return []ApplicationSource{app.Spec.Source}
+}
+
+// usesRepo reports whether any of an application's sources is repo,
+// compared the way ArgoCD normalizes repository URLs
+func usesRepo(app *ArgocdApplication, repo string) bool {
+	repo = normalizeRepoURL(repo)
+	for _, source := range app.sources() {
+		if normalizeRepoURL(source.RepoURL) == repo {
+			return true
+		}
+	}
+	return false
+}
+
+// summarizeApplication builds the compact view of an application. Multi-source
+// applications are summarized by their first source.
+func summarizeApplication(app *ArgocdApplication) ApplicationSummary {
+	source := app.sources()[0]
+	return ApplicationSummary{
+		Name:                 app.Metadata.Name,
+		Project:              app.Spec.Project,
+		SyncStatus:           app.Status.Sync.Status,
+		HealthStatus:         app.Status.Health.Status,
+		RepoURL:              source.RepoURL,
+		Path:                 source.Path,
+		TargetRevision:       source.TargetRevision,
+		DestinationServer:    app.Spec.Destination.Server,
+		DestinationNamespace: app.Spec.Destination.Namespace,
+	}
+}
+
+// getApplication fetches a single application by name
+func (s *MCPServer) getApplication(ctx context.Context, name string) (*ArgocdApplication, error) {
+	return s.getApplicationWithQuery(ctx, name, nil)
+}
+
+// getApplicationWithQuery fetches a single application, passing query
+// parameters such as appNamespace or project through to ArgoCD
+func (s *MCPServer) getApplicationWithQuery(ctx context.Context, name string, query url.Values) (*ArgocdApplication, error) {
+	var app ArgocdApplication
+	if err := s.argocdGet(ctx, applicationPath(name), query, &app); err != nil {
+		return nil, err
+	}
+	s.history.record(s.instance(ctx).name, []ArgocdApplication{app})
+	return &app, nil
+}
+
+func (s *MCPServer) handleGetApplicationMetadata(ctx context.Context, req *mcp.CallToolRequest, args ApplicationNameArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	if args.Name == "" {
+		return toolError(fmt.Errorf("name is required"))
+	}
+
+	app, err := s.getApplication(ctx, args.Name)
+	if err != nil {
+		return toolError(err)
+	}
+
+	metadata := ApplicationMetadata{
+		Name:              app.Metadata.Name,
+		Namespace:         app.Metadata.Namespace,
+		CreationTimestamp: app.Metadata.CreationTimestamp,
+		DeletionTimestamp: app.Metadata.DeletionTimestamp,
+		Finalizers:        app.Metadata.Finalizers,
+		Labels:            app.Metadata.Labels,
+		Annotations:       app.Metadata.Annotations,
+	}
+	// Return empty collections rather than null so clients can iterate safely
+	if metadata.Finalizers == nil {
+		metadata.Finalizers = []string{}
+	}
+	if metadata.Labels == nil {
+		metadata.Labels = map[string]string{}
+	}
+	if metadata.Annotations == nil {
+		metadata.Annotations = map[string]string{}
+	}
+
+	return toolJSON(metadata)
+}
+
+func (s *MCPServer) handleGetApplicationURLs(ctx context.Context, req *mcp.CallToolRequest, args ApplicationNameArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	if args.Name == "" {
+		return toolError(fmt.Errorf("name is required"))
+	}
+
+	app, err := s.getApplication(ctx, args.Name)
+	if err != nil {
+		return toolError(err)
+	}
+
+	urls := ApplicationURLs{
+		Name:         app.Metadata.Name,
+		ExternalURLs: app.Status.Summary.ExternalURLs,
+		Images:       app.Status.Summary.Images,
+	}
+	if urls.ExternalURLs == nil {
+		urls.ExternalURLs = []string{}
+	}
+	if urls.Images == nil {
+		urls.Images = []string{}
+	}
+
+	return toolJSON(urls)
+}
+
+// ApplicationsByCreationArgs are the arguments for the list_applications_by_creation tool
+type ApplicationsByCreationArgs struct {
+	CreatedWithinDays int  `json:"created_within_days,omitempty" jsonschema:"only include applications created in the last N days"`
+	OldestFirst       bool `json:"oldest_first,omitempty" jsonschema:"sort oldest first instead of newest first, e.g. to find forgotten test apps"`
+	Limit             int  `json:"limit,omitempty" jsonschema:"maximum number of applications to return"`
+}
+
+// ApplicationAge is an application with its creation time
+type ApplicationAge struct {
+	Name      string `json:"name"`
+	Project   string `json:"project"`
+	CreatedAt string `json:"created_at"`
+	Age       string `json:"age"`
+}
+
+// formatAge renders a duration in the largest sensible unit, e.g. "3 days"
+func formatAge(d time.Duration) string {
+	plural := func(n int, unit string) string {
+		if n == 1 {
+			return fmt.Sprintf("1 %s", unit)
+		}
+		return fmt.Sprintf("%d %ss", n, unit)
+	}
+	switch {
+	case d >= 24*time.Hour:
+		return plural(int(d/(24*time.Hour)), "day")
+	case d >= time.Hour:
+		return plural(int(d/time.Hour), "hour")
+	default:
+		return plural(int(d/time.Minute), "minute")
+	}
+}
+
+func (s *MCPServer) handleListApplicationsByCreation(ctx context.Context, req *mcp.CallToolRequest, args ApplicationsByCreationArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	apps, err := s.getArgocdApplications(ctx, ApplicationFilter{})
+	if err != nil {
+		return toolError(err)
+	}
+
+	now := time.Now()
+	type dated struct {
+		app     *ArgocdApplication
+		created time.Time
+	}
+	var items []dated
+	for i := range apps.Items {
+		app := &apps.Items[i]
+		created, err := time.Parse(time.RFC3339, app.Metadata.CreationTimestamp)
+		if err != nil {
+			continue
+		}
+		if args.CreatedWithinDays > 0 && now.Sub(created) > time.Duration(args.CreatedWithinDays)*24*time.Hour {
+			continue
+		}
+		items = append(items, dated{app, created})
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		if args.OldestFirst {
+			return items[i].created.Before(items[j].created)
+		}
+		return items[i].created.After(items[j].created)
+	})
+	if args.Limit > 0 && len(items) > args.Limit {
+		items = items[:args.Limit]
+	}
+
+	result := make([]ApplicationAge, 0, len(items))
+	for _, item := range items {
+		result = append(result, ApplicationAge{
+			Name:      item.app.Metadata.Name,
+			Project:   item.app.Spec.Project,
+			CreatedAt: item.created.UTC().Format("2006-01-02 15:04 MST"),
+			Age:       formatAge(now.Sub(item.created)),
+		})
+	}
+
+	return toolJSON(result)
+}
+
+// getApplicationQuery builds the query for get_application. Only the
+// namespace falls back to ARGOCD_DEFAULT_APP_NAMESPACE: project is a filter,
+// and defaulting it would hide applications in every other project.
+func (s *MCPServer) getApplicationQuery(args GetApplicationArgs) url.Values {
+	query := url.Values{}
+	if namespace := firstNonEmpty(args.Namespace, s.defaultAppNamespace); namespace != "" {
+		query.Set("appNamespace", namespace)
+	}
+	if args.Project != "" {
+		query.Set("project", args.Project)
+	}
+	return query
+}
+
+func (s *MCPServer) handleGetApplication(ctx context.Context, req *mcp.CallToolRequest, args GetApplicationArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	if args.Name == "" {
+		return toolError(fmt.Errorf("name is required"))
+	}
+
+	app, err := s.getApplicationWithQuery(ctx, args.Name, s.getApplicationQuery(args))
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrNotFound):
+			err = fmt.Errorf("application %q not found: %w", args.Name, err)
+		case errors.Is(err, ErrUnauthorized), errors.Is(err, ErrForbidden):
+			err = fmt.Errorf("not authorized to get application %q: %w", args.Name, err)
+		}
+		return toolError(err)
+	}
+
+	return toolJSON(app)
+}
+
+func (s *MCPServer) handleGetApplicationStatus(ctx context.Context, req *mcp.CallToolRequest, args ApplicationNameArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	if args.Name == "" {
+		return toolError(fmt.Errorf("name is required"))
+	}
+
+	app, err := s.getApplication(ctx, args.Name)
+	if err != nil {
+		return toolError(err)
+	}
+
+	return toolJSON(ApplicationStatus{
+		Name:         app.Metadata.Name,
+		SyncStatus:   app.Status.Sync.Status,
+		HealthStatus: app.Status.Health.Status,
+		Revision:     app.Status.Sync.Revision,
+	})
+}
+
+func (s *MCPServer) handleListApplications(ctx context.Context, req *mcp.CallToolRequest, args ListApplicationsArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	apps, err := s.getArgocdApplications(ctx, ApplicationFilter{
+		Project:  args.Project,
+		Selector: args.Selector,
+		Repo:     args.Repo,
+		Cluster:  args.Cluster,
+		Health:   args.HealthStatus,
+		Sync:     args.SyncStatus,
+	})
+	if err != nil {
+		return toolError(err)
+	}
+
+	// No matches is an empty list, not an error
+	summaries := make([]ApplicationSummary, len(apps.Items))
+	for i := range apps.Items {
+		summaries[i] = summarizeApplication(&apps.Items[i])
+	}
+
+	return toolJSON(summaries)
+}
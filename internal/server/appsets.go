@@ -0,0 +1,151 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ApplicationSet represents an ArgoCD ApplicationSet
+type ApplicationSet struct {
+	Metadata struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace,omitempty"`
+	} `json:"metadata"`
+	Spec struct {
+		// Generators are kept loosely typed: each is an object with a single
+		// key naming its kind (list, clusters, git, matrix, ...)
+		Generators []map[string]any `json:"generators"`
+		Template   struct {
+			Spec struct {
+				Project string              `json:"project"`
+				Source  *ApplicationSource  `json:"source,omitempty"`
+				Sources []ApplicationSource `json:"sources,omitempty"`
+			} `json:"spec"`
+		} `json:"template"`
+	} `json:"spec"`
+	Status struct {
+		// Resources are the applications the set generated. ArgoCD reports
+		// them from 2.8 on.
+		Resources []struct {
+			Name string `json:"name"`
+		} `json:"resources,omitempty"`
+	} `json:"status"`
+}
+
+// ApplicationSetList represents a list of ArgoCD ApplicationSets
+type ApplicationSetList struct {
+	Items []ApplicationSet `json:"items"`
+}
+
+// ApplicationSetSummary is a compact view of an ApplicationSet
+type ApplicationSetSummary struct {
+	Name       string   `json:"name"`
+	Namespace  string   `json:"namespace,omitempty"`
+	Project    string   `json:"project,omitempty"`
+	Generators []string `json:"generators"`
+	// RepoURLs are the sources of the application template
+	RepoURLs              []string `json:"repo_urls"`
+	GeneratedApplications int      `json:"generated_applications"`
+	Applications          []string `json:"applications"`
+}
+
+// summarizeGenerator describes a generator in a few words, e.g.
+// "git https://github.com/org/apps (directories)" or "matrix(clusters, list)"
+func summarizeGenerator(generator map[string]any) string {
+	kinds := make([]string, 0, len(generator))
+	for kind := range generator {
+		// Selectors and template overrides sit beside the generator
+		if kind != "selector" && kind != "template" {
+			kinds = append(kinds, kind)
+		}
+	}
+	if len(kinds) != 1 {
+		sort.Strings(kinds)
+		return strings.Join(kinds, "+")
+	}
+	kind := kinds[0]
+	spec, _ := generator[kind].(map[string]any)
+
+	switch kind {
+	case "list":
+		elements, _ := spec["elements"].([]any)
+		return fmt.Sprintf("list (%d elements)", len(elements))
+	case "git":
+		repo, _ := spec["repoURL"].(string)
+		mode := "files"
+		if _, ok := spec["directories"]; ok {
+			mode = "directories"
+		}
+		return fmt.Sprintf("git %s (%s)", repo, mode)
+	case "scmProvider", "pullRequest":
+		// The provider is the one object-valued setting, e.g. github
+		providers := []string{}
+		for key, value := range spec {
+			if _, ok := value.(map[string]any); ok && key != "template" {
+				providers = append(providers, key)
+			}
+		}
+		sort.Strings(providers)
+		return strings.TrimSpace(kind + " " + strings.Join(providers, "+"))
+	case "matrix", "merge":
+		children, _ := spec["generators"].([]any)
+		parts := make([]string, 0, len(children))
+		for _, child := range children {
+			if g, ok := child.(map[string]any); ok {
+				parts = append(parts, summarizeGenerator(g))
+			}
+		}
+		return kind + "(" + strings.Join(parts, ", ") + ")"
+	}
+	return kind
+}
+
+// summarizeApplicationSet builds the compact view of an ApplicationSet
+func summarizeApplicationSet(set *ApplicationSet) ApplicationSetSummary {
+	summary := ApplicationSetSummary{
+		Name:         set.Metadata.Name,
+		Namespace:    set.Metadata.Namespace,
+		Project:      set.Spec.Template.Spec.Project,
+		Generators:   make([]string, len(set.Spec.Generators)),
+		RepoURLs:     []string{},
+		Applications: make([]string, len(set.Status.Resources)),
+	}
+	for i, generator := range set.Spec.Generators {
+		summary.Generators[i] = summarizeGenerator(generator)
+	}
+	if source := set.Spec.Template.Spec.Source; source != nil && source.RepoURL != "" {
+		summary.RepoURLs = append(summary.RepoURLs, source.RepoURL)
+	}
+	for _, source := range set.Spec.Template.Spec.Sources {
+		summary.RepoURLs = append(summary.RepoURLs, source.RepoURL)
+	}
+	for i, resource := range set.Status.Resources {
+		summary.Applications[i] = resource.Name
+	}
+	sort.Strings(summary.Applications)
+	summary.GeneratedApplications = len(summary.Applications)
+	return summary
+}
+
+func (s *MCPServer) handleListApplicationSets(ctx context.Context, req *mcp.CallToolRequest, args struct{}) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	var sets ApplicationSetList
+	if err := s.argocdGet(ctx, "/api/v1/applicationsets", nil, &sets); err != nil {
+		return toolError(err)
+	}
+
+	summaries := make([]ApplicationSetSummary, len(sets.Items))
+	for i := range sets.Items {
+		summaries[i] = summarizeApplicationSet(&sets.Items[i])
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Name < summaries[j].Name
+	})
+
+	return toolJSON(summaries)
+}
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// argocdGet performs an authenticated GET against the ArgoCD API and decodes
+// the JSON response into out
+func (s *MCPServer) argocdGet(ctx context.Context, path string, query url.Values, out any) error {
+	return s.argocdDo(ctx, "GET", path, query, nil, out)
+}
+
+// argocdDo performs an authenticated request against the ArgoCD API. A non-nil
+// in is sent as the JSON request body, and the JSON response is decoded into
+// out when out is non-nil.
+func (s *MCPServer) argocdDo(ctx context.Context, method, path string, query url.Values, in, out any) error {
+	respBody, err := s.doArgocdRequest(ctx, method, path, query, in)
+	if err != nil {
+		return err
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return nil
+}
+
+// doArgocdRequest performs an authenticated request against the ArgoCD API of
+// the instance selected for ctx and returns the raw response body. A non-nil
+// in is sent as the JSON request body. Statuses other than 200 are returned
+// as an *APIError, and bodies over maxResponseBytes are rejected.
+func (s *MCPServer) doArgocdRequest(ctx context.Context, method, path string, query url.Values, in any) ([]byte, error) {
+	ctx, cancel := s.callContext(ctx)
+	defer cancel()
+
+	reqURL := s.serverURL(ctx) + path
+	if len(query) > 0 {
+		reqURL += "?" + query.Encode()
+	}
+
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	// Writes can change any application, so cached lists are no longer trusted
+	if method != "GET" {
+		s.appCache.invalidate()
+	}
+
+	respBody, err := readLimited(resp.Body, s.maxResponseBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	return respBody, nil
+}
+
+// applicationPath returns the API path for a single application
+func applicationPath(name string) string {
+	return "/api/v1/applications/" + url.PathEscape(name)
+}
@@ -0,0 +1,159 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// readTokenFile reads an auth token from path, as mounted from a secret.
+// Surrounding whitespace, such as a trailing newline, is dropped.
+func readTokenFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read auth token file: %w", err)
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("auth token file %s is empty", path)
+	}
+	return token, nil
+}
+
+// authToken returns the token currently used to authenticate to ArgoCD
+func (i *argocdInstance) authToken() string {
+	i.authMu.RLock()
+	defer i.authMu.RUnlock()
+	return i.cfg.AuthToken
+}
+
+// canLogin reports whether the server can obtain session tokens itself from
+// a configured username and password
+func (i *argocdInstance) canLogin() bool {
+	return i.cfg.Username != "" && i.cfg.Password != ""
+}
+
+// canReauthenticate reports whether a rejected token can be replaced, by
+// re-reading the token file or logging in again
+func (i *argocdInstance) canReauthenticate() bool {
+	return i.cfg.AuthTokenFile != "" || i.canLogin()
+}
+
+// reauthenticate replaces staleToken after ArgoCD rejected it. It reports
+// false when there is no new token to retry with, i.e. the token file still
+// holds the rejected token.
+func (i *argocdInstance) reauthenticate(ctx context.Context, staleToken string) (bool, error) {
+	if i.cfg.AuthTokenFile == "" {
+		return true, i.login(ctx, staleToken)
+	}
+
+	i.authMu.Lock()
+	defer i.authMu.Unlock()
+
+	if i.cfg.AuthToken != staleToken {
+		return true, nil
+	}
+	token, err := readTokenFile(i.cfg.AuthTokenFile)
+	if err != nil {
+		return false, err
+	}
+	if token == staleToken {
+		return false, nil
+	}
+	i.cfg.AuthToken = token
+	return true, nil
+}
+
+// login exchanges the configured username and password for a session token.
+// If the token changed since the caller last used staleToken, another request
+// already logged in and the new token is kept.
+func (i *argocdInstance) login(ctx context.Context, staleToken string) error {
+	i.authMu.Lock()
+	defer i.authMu.Unlock()
+
+	if i.cfg.AuthToken != staleToken {
+		return nil
+	}
+
+	data, err := json.Marshal(map[string]string{
+		"username": i.cfg.Username,
+		"password": i.cfg.Password,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal login request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", i.cfg.ServerURL+"/api/v1/session", bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create login request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := i.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to log in: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to log in as %q: %w", i.cfg.Username, newAPIError(resp))
+	}
+
+	var session struct {
+		Token string `json:"token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&session); err != nil {
+		return fmt.Errorf("failed to decode login response: %w", err)
+	}
+	if session.Token == "" {
+		return fmt.Errorf("login as %q returned no token", i.cfg.Username)
+	}
+
+	i.cfg.AuthToken = session.Token
+	return nil
+}
+
+// do sends an ArgoCD API request with the current credentials. When ArgoCD
+// rejects the token (typically because it expired or was rotated) and the
+// server logs in with a username and password or reads the token from a
+// file, it obtains a new token and retries once.
+func (s *MCPServer) do(req *http.Request) (resp *http.Response, err error) {
+	defer func() { countArgocdError(resp, err) }()
+
+	inst := s.instance(req.Context())
+	token := inst.authToken()
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err = s.send(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || !inst.canReauthenticate() {
+		return resp, err
+	}
+
+	refreshed, err := inst.reauthenticate(req.Context(), token)
+	if err != nil {
+		resp.Body.Close()
+		return nil, fmt.Errorf("re-authentication failed: %w", err)
+	}
+	if !refreshed {
+		// Nothing new to try, so report the original rejection
+		return resp, nil
+	}
+	resp.Body.Close()
+
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("failed to replay request body: %w", err)
+		}
+		retry.Body = body
+	}
+	retry.Header.Set("Authorization", "Bearer "+inst.authToken())
+	return s.send(retry)
+}
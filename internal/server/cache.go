@@ -0,0 +1,74 @@
+package server
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// defaultCacheTTL is used when ARGOCD_CACHE_TTL is unset or invalid
+	defaultCacheTTL = 10 * time.Second
+)
+
+// appListCache keeps recent application list responses, keyed by the query
+// sent to ArgoCD, so an agent polling the list doesn't hit the API each time
+type appListCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]appListCacheEntry
+}
+
+type appListCacheEntry struct {
+	items     []ArgocdApplication
+	fetchedAt time.Time
+}
+
+// newAppListCache creates a cache; a ttl of zero or less disables it
+func newAppListCache(ttl time.Duration) *appListCache {
+	return &appListCache{
+		ttl:     ttl,
+		entries: make(map[string]appListCacheEntry),
+	}
+}
+
+// get returns a copy of the cached items for key if they are still fresh
+func (c *appListCache) get(key string) ([]ArgocdApplication, bool) {
+	if c.ttl <= 0 {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || time.Since(entry.fetchedAt) > c.ttl {
+		delete(c.entries, key)
+		return nil, false
+	}
+	// Callers filter the slice in place, so never hand out the cached one
+	return append([]ArgocdApplication(nil), entry.items...), true
+}
+
+// put stores a copy of items under key
+func (c *appListCache) put(key string, items []ArgocdApplication) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = appListCacheEntry{
+		items:     append([]ArgocdApplication(nil), items...),
+		fetchedAt: time.Now(),
+	}
+}
+
+// invalidate drops every cached entry, e.g. after a write to ArgoCD
+func (c *appListCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	clear(c.entries)
+}
@@ -0,0 +1,335 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ClusterLatencyArgs are the arguments for the get_cluster_latency tool
+type ClusterLatencyArgs struct {
+	Probe bool `json:"probe,omitempty" jsonschema:"actively time a cluster lookup through the ArgoCD API for each cluster"`
+}
+
+// ClusterLatency reports connection timing data for a single cluster
+type ClusterLatency struct {
+	Name                string `json:"name"`
+	Server              string `json:"server"`
+	ConnectionStatus    string `json:"connection_status"`
+	LastStateChange     string `json:"last_state_change,omitempty"`
+	StateAgeSeconds     int64  `json:"state_age_seconds,omitempty"`
+	LastCacheSync       string `json:"last_cache_sync,omitempty"`
+	CacheSyncAgeSeconds int64  `json:"cache_sync_age_seconds,omitempty"`
+	ProbeLatencyMs      int64  `json:"probe_latency_ms,omitempty"`
+	ProbeError          string `json:"probe_error,omitempty"`
+}
+
+// ClusterLatencyReport is the result of the get_cluster_latency tool
+type ClusterLatencyReport struct {
+	SortedBy string           `json:"sorted_by"`
+	Note     string           `json:"note"`
+	Clusters []ClusterLatency `json:"clusters"`
+}
+
+// ListClustersArgs are the arguments for the list_clusters tool
+type ListClustersArgs struct {
+	Name             string   `json:"name,omitempty" jsonschema:"only the cluster with this name, e.g. in-cluster"`
+	Server           string   `json:"server,omitempty" jsonschema:"only the cluster with this API server URL"`
+	ConnectionStatus []string `json:"connection_status,omitempty" jsonschema:"only clusters whose connection status is one of these (Successful, Failed or Unknown; case-insensitive)"`
+}
+
+// GetClusterArgs are the arguments for the get_cluster tool
+type GetClusterArgs struct {
+	Server string `json:"server" jsonschema:"the cluster's API server URL, e.g. https://kubernetes.default.svc"`
+}
+
+// clusterPath returns the API path for a single cluster identified by its server URL
+func clusterPath(server string) string {
+	return "/api/v1/clusters/" + url.PathEscape(server)
+}
+
+// connectionState returns the cluster's connection status, message and last
+// change time. Newer ArgoCD versions report these under info rather than at
+// the top level.
+func (c *Cluster) connectionState() (status, message, modifiedAt string) {
+	if c.ConnectionState.Status != "" {
+		return c.ConnectionState.Status, c.ConnectionState.Message, c.ConnectionState.ModifiedAt
+	}
+	return c.Info.ConnectionState.Status, c.Info.ConnectionState.Message, c.Info.ConnectionState.ModifiedAt
+}
+
+// getCluster fetches a single registered cluster by its server URL
+func (s *MCPServer) getCluster(ctx context.Context, server string) (*Cluster, error) {
+	var cluster Cluster
+	if err := s.argocdGet(ctx, clusterPath(server), nil, &cluster); err != nil {
+		return nil, err
+	}
+	return &cluster, nil
+}
+
+func (s *MCPServer) handleGetCluster(ctx context.Context, req *mcp.CallToolRequest, args GetClusterArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	if args.Server == "" {
+		return toolError(fmt.Errorf("server is required"))
+	}
+
+	cluster, err := s.getCluster(ctx, args.Server)
+	if err != nil {
+		// ArgoCD answers 403 rather than 404 for unknown clusters so it doesn't
+		// reveal which exist
+		if errors.Is(err, ErrNotFound) || errors.Is(err, ErrForbidden) {
+			err = fmt.Errorf("cluster %q is not registered in ArgoCD or not visible to this account: %w", args.Server, err)
+		}
+		return toolError(err)
+	}
+
+	return toolJSON(cluster)
+}
+
+// sortClusterLatencies orders clusters slowest first, by probe latency when
+// probed and by cache sync age otherwise. Clusters whose probe failed come
+// first: they have no latency, and are the likeliest cause of slow syncs.
+func sortClusterLatencies(latencies []ClusterLatency, probed bool) {
+	sort.SliceStable(latencies, func(i, j int) bool {
+		if !probed {
+			return latencies[i].CacheSyncAgeSeconds > latencies[j].CacheSyncAgeSeconds
+		}
+		iFailed, jFailed := latencies[i].ProbeError != "", latencies[j].ProbeError != ""
+		if iFailed != jFailed {
+			return iFailed
+		}
+		return latencies[i].ProbeLatencyMs > latencies[j].ProbeLatencyMs
+	})
+}
+
+func (s *MCPServer) handleClusterLatency(ctx context.Context, req *mcp.CallToolRequest, args ClusterLatencyArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	clusters, err := s.getClusters(ctx)
+	if err != nil {
+		return toolError(err)
+	}
+
+	now := time.Now()
+	latencies := make([]ClusterLatency, len(clusters.Items))
+	for i := range clusters.Items {
+		cluster := &clusters.Items[i]
+		status, _, modifiedAt := cluster.connectionState()
+		latency := ClusterLatency{
+			Name:             cluster.Name,
+			Server:           cluster.Server,
+			ConnectionStatus: status,
+			LastStateChange:  modifiedAt,
+			LastCacheSync:    cluster.Info.CacheInfo.LastCacheSyncTime,
+		}
+		if t, err := time.Parse(time.RFC3339, latency.LastStateChange); err == nil {
+			latency.StateAgeSeconds = int64(now.Sub(t).Seconds())
+		}
+		if t, err := time.Parse(time.RFC3339, latency.LastCacheSync); err == nil {
+			latency.CacheSyncAgeSeconds = int64(now.Sub(t).Seconds())
+		}
+		latencies[i] = latency
+	}
+
+	report := &ClusterLatencyReport{
+		SortedBy: "cache_sync_age_seconds",
+		Note:     "Ages are derived from ArgoCD's recorded connection state and cache sync times; a large cache sync age suggests a slow or struggling cluster.",
+	}
+
+	if args.Probe {
+		forEachConcurrent(ctx, len(latencies), func(ctx context.Context, i int) {
+			var cluster Cluster
+			start := time.Now()
+			if err := s.argocdGet(ctx, clusterPath(latencies[i].Server), nil, &cluster); err != nil {
+				latencies[i].ProbeError = err.Error()
+				return
+			}
+			latencies[i].ProbeLatencyMs = time.Since(start).Milliseconds()
+		})
+		report.SortedBy = "probe_latency_ms"
+		report.Note += " Probe latency is the round trip of a cluster lookup through the ArgoCD API server, measured from this MCP server."
+	}
+
+	sortClusterLatencies(latencies, args.Probe)
+	report.Clusters = latencies
+
+	return toolJSON(report)
+}
+
+// UnreachableCluster is a cluster whose connection is failed or unknown
+type UnreachableCluster struct {
+	Name    string `json:"name"`
+	Server  string `json:"server"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// AffectedApplication is an application deploying to an unreachable cluster
+type AffectedApplication struct {
+	Name      string `json:"name"`
+	Project   string `json:"project"`
+	Cluster   string `json:"cluster"`
+	Server    string `json:"server"`
+	Namespace string `json:"namespace"`
+}
+
+// UnreachableClustersReport is the result of the list_apps_on_unreachable_clusters tool
+type UnreachableClustersReport struct {
+	UnreachableClusters []UnreachableCluster  `json:"unreachable_clusters"`
+	Applications        []AffectedApplication `json:"applications"`
+}
+
+func (s *MCPServer) handleListAppsOnUnreachableClusters(ctx context.Context, req *mcp.CallToolRequest, args struct{}) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	clusters, err := s.getClusters(ctx)
+	if err != nil {
+		return toolError(err)
+	}
+
+	report := &UnreachableClustersReport{
+		UnreachableClusters: []UnreachableCluster{},
+		Applications:        []AffectedApplication{},
+	}
+
+	byServer := map[string]*UnreachableCluster{}
+	byName := map[string]*UnreachableCluster{}
+	for i := range clusters.Items {
+		cluster := &clusters.Items[i]
+		status, message, _ := cluster.connectionState()
+		if status != "Failed" && status != "Unknown" {
+			continue
+		}
+		report.UnreachableClusters = append(report.UnreachableClusters, UnreachableCluster{
+			Name:    cluster.Name,
+			Server:  cluster.Server,
+			Status:  status,
+			Message: message,
+		})
+	}
+	if len(report.UnreachableClusters) == 0 {
+		return toolJSON(report)
+	}
+	for i := range report.UnreachableClusters {
+		uc := &report.UnreachableClusters[i]
+		byServer[uc.Server] = uc
+		byName[uc.Name] = uc
+	}
+
+	apps, err := s.getArgocdApplications(ctx, ApplicationFilter{})
+	if err != nil {
+		return toolError(err)
+	}
+
+	for _, app := range apps.Items {
+		dest := app.Spec.Destination
+		uc, ok := byServer[dest.Server]
+		if !ok && dest.Server == "" {
+			uc, ok = byName[dest.Name]
+		}
+		if !ok {
+			continue
+		}
+		report.Applications = append(report.Applications, AffectedApplication{
+			Name:      app.Metadata.Name,
+			Project:   app.Spec.Project,
+			Cluster:   uc.Name,
+			Server:    uc.Server,
+			Namespace: dest.Namespace,
+		})
+	}
+
+	sort.Slice(report.Applications, func(i, j int) bool {
+		return report.Applications[i].Name < report.Applications[j].Name
+	})
+
+	return toolJSON(report)
+}
+
+// ClusterSummary is a compact view of a registered cluster
+type ClusterSummary struct {
+	Name              string `json:"name"`
+	Server            string `json:"server"`
+	ConnectionStatus  string `json:"connection_status"`
+	ServerVersion     string `json:"server_version,omitempty"`
+	ApplicationsCount int    `json:"applications_count"`
+	// Message explains a Failed connection status
+	Message string `json:"message,omitempty"`
+}
+
+// summarizeCluster builds the compact view of a cluster
+func summarizeCluster(cluster *Cluster) ClusterSummary {
+	status, message, _ := cluster.connectionState()
+	return ClusterSummary{
+		Name:              cluster.Name,
+		Server:            cluster.Server,
+		ConnectionStatus:  status,
+		ServerVersion:     firstNonEmpty(cluster.Info.ServerVersion, cluster.ServerVersion),
+		ApplicationsCount: cluster.Info.ApplicationsCount,
+		Message:           message,
+	}
+}
+
+// ClusterSummaryReport is the result of the cluster_summary tool
+type ClusterSummaryReport struct {
+	TotalApplications int              `json:"total_applications"`
+	Clusters          []ClusterSummary `json:"clusters"`
+}
+
+func (s *MCPServer) handleClusterSummary(ctx context.Context, req *mcp.CallToolRequest, args struct{}) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	clusters, err := s.getClusters(ctx)
+	if err != nil {
+		return toolError(err)
+	}
+
+	report := &ClusterSummaryReport{Clusters: make([]ClusterSummary, len(clusters.Items))}
+	for i := range clusters.Items {
+		report.Clusters[i] = summarizeCluster(&clusters.Items[i])
+		report.TotalApplications += clusters.Items[i].Info.ApplicationsCount
+	}
+	sort.Slice(report.Clusters, func(i, j int) bool {
+		return report.Clusters[i].Name < report.Clusters[j].Name
+	})
+
+	return toolJSON(report)
+}
+
+func (s *MCPServer) handleListClusters(ctx context.Context, req *mcp.CallToolRequest, args ListClustersArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	query := url.Values{}
+	if args.Name != "" {
+		query.Set("name", args.Name)
+	}
+	if args.Server != "" {
+		query.Set("server", args.Server)
+	}
+	clusters, err := s.getClustersWithQuery(ctx, query)
+	if err != nil {
+		return toolError(err)
+	}
+
+	summaries := []ClusterSummary{}
+	for i := range clusters.Items {
+		summary := summarizeCluster(&clusters.Items[i])
+		// Clusters no application has used yet have no connection state
+		summary.ConnectionStatus = firstNonEmpty(summary.ConnectionStatus, "Unknown")
+		if !matchesStatus(summary.ConnectionStatus, args.ConnectionStatus) {
+			continue
+		}
+		summaries = append(summaries, summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Name < summaries[j].Name
+	})
+
+	return toolJSON(summaries)
+}
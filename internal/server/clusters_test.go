@@ -0,0 +1,32 @@
+package server
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSortClusterLatencies(t *testing.T) {
+	latencies := []ClusterLatency{
+		{Name: "fast", ProbeLatencyMs: 20, CacheSyncAgeSeconds: 300},
+		{Name: "down", ProbeError: "connection refused", CacheSyncAgeSeconds: 10},
+		{Name: "slow", ProbeLatencyMs: 900, CacheSyncAgeSeconds: 60},
+	}
+
+	sortClusterLatencies(latencies, true)
+	if got, want := clusterNames(latencies), []string{"down", "slow", "fast"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("probed order = %v, want %v", got, want)
+	}
+
+	sortClusterLatencies(latencies, false)
+	if got, want := clusterNames(latencies), []string{"fast", "slow", "down"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unprobed order = %v, want %v", got, want)
+	}
+}
+
+func clusterNames(latencies []ClusterLatency) []string {
+	names := make([]string, len(latencies))
+	for i, l := range latencies {
+		names[i] = l.Name
+	}
+	return names
+}
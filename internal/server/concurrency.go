@@ -0,0 +1,35 @@
+package server
+
+import (
+	"context"
+	"sync"
+)
+
+// maxConcurrentRequests bounds how many ArgoCD calls fleet-wide tools make at once
+const maxConcurrentRequests = 5
+
+// forEachConcurrent calls fn for every index in [0, n) with at most
+// maxConcurrentRequests calls in flight. No new calls are started once ctx is
+// cancelled; fn is expected to observe ctx for calls already running.
+func forEachConcurrent(ctx context.Context, n int, fn func(ctx context.Context, i int)) {
+	sem := make(chan struct{}, maxConcurrentRequests)
+	var wg sync.WaitGroup
+
+	for i := 0; i < n; i++ {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fn(ctx, i)
+		}(i)
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,193 @@
+package server
+
+import (
+	"context"
+	"sort"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Condition types that mean ArgoCD cannot reconcile an application
+const (
+	conditionSyncError       = "SyncError"
+	conditionComparisonError = "ComparisonError"
+)
+
+// ApplicationCondition is a condition reported in an application's status
+type ApplicationCondition struct {
+	Type               string `json:"type"`
+	Message            string `json:"message"`
+	LastTransitionTime string `json:"lastTransitionTime,omitempty"`
+}
+
+// reconcileErrors returns the SyncError and ComparisonError conditions of an app
+func reconcileErrors(app *ArgocdApplication) []ApplicationCondition {
+	var errs []ApplicationCondition
+	for _, cond := range app.Status.Conditions {
+		if cond.Type == conditionSyncError || cond.Type == conditionComparisonError {
+			errs = append(errs, cond)
+		}
+	}
+	return errs
+}
+
+// SilentFailure is a Healthy application that ArgoCD can't reconcile
+type SilentFailure struct {
+	Name         string                 `json:"name"`
+	Project      string                 `json:"project"`
+	HealthStatus string                 `json:"health_status"`
+	SyncStatus   string                 `json:"sync_status"`
+	Conditions   []ApplicationCondition `json:"conditions"`
+}
+
+func (s *MCPServer) handleFindSilentFailures(ctx context.Context, req *mcp.CallToolRequest, args struct{}) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	apps, err := s.getArgocdApplications(ctx, ApplicationFilter{})
+	if err != nil {
+		return toolError(err)
+	}
+
+	failures := []SilentFailure{}
+	for i := range apps.Items {
+		app := &apps.Items[i]
+		if app.Status.Health.Status != "Healthy" {
+			continue
+		}
+		errs := reconcileErrors(app)
+		if len(errs) == 0 {
+			continue
+		}
+		failures = append(failures, SilentFailure{
+			Name:         app.Metadata.Name,
+			Project:      app.Spec.Project,
+			HealthStatus: app.Status.Health.Status,
+			SyncStatus:   app.Status.Sync.Status,
+			Conditions:   errs,
+		})
+	}
+
+	sort.Slice(failures, func(i, j int) bool {
+		return failures[i].Name < failures[j].Name
+	})
+
+	return toolJSON(failures)
+}
+
+// ReconciliationErrorRate is the result of the get_reconciliation_error_rate tool
+type ReconciliationErrorRate struct {
+	TotalApplications int            `json:"total_applications"`
+	ErroredCount      int            `json:"errored_count"`
+	ErrorRate         float64        `json:"error_rate"`
+	ByType            map[string]int `json:"by_type"`
+	Applications      []string       `json:"applications"`
+}
+
+func (s *MCPServer) handleGetReconciliationErrorRate(ctx context.Context, req *mcp.CallToolRequest, args struct{}) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	apps, err := s.getArgocdApplications(ctx, ApplicationFilter{})
+	if err != nil {
+		return toolError(err)
+	}
+
+	rate := &ReconciliationErrorRate{
+		TotalApplications: len(apps.Items),
+		ByType: map[string]int{
+			conditionSyncError:       0,
+			conditionComparisonError: 0,
+		},
+		Applications: []string{},
+	}
+	for i := range apps.Items {
+		errs := reconcileErrors(&apps.Items[i])
+		if len(errs) == 0 {
+			continue
+		}
+		// Count each condition type once per app so by_type reads as app counts
+		seen := map[string]bool{}
+		for _, cond := range errs {
+			if !seen[cond.Type] {
+				seen[cond.Type] = true
+				rate.ByType[cond.Type]++
+			}
+		}
+		rate.Applications = append(rate.Applications, apps.Items[i].Metadata.Name)
+	}
+
+	rate.ErroredCount = len(rate.Applications)
+	if rate.TotalApplications > 0 {
+		rate.ErrorRate = float64(rate.ErroredCount) / float64(rate.TotalApplications)
+	}
+	sort.Strings(rate.Applications)
+
+	return toolJSON(rate)
+}
+
+// ConditionOccurrence is an application affected by a condition
+type ConditionOccurrence struct {
+	Name    string `json:"name"`
+	Message string `json:"message"`
+}
+
+// ConditionGroup is every application reporting a given condition type
+type ConditionGroup struct {
+	Type         string                `json:"type"`
+	Count        int                   `json:"count"`
+	Applications []ConditionOccurrence `json:"applications"`
+}
+
+// FleetConditions is the result of the fleet_conditions tool
+type FleetConditions struct {
+	TotalApplications    int              `json:"total_applications"`
+	AffectedApplications int              `json:"affected_applications"`
+	Conditions           []ConditionGroup `json:"conditions"`
+}
+
+func (s *MCPServer) handleFleetConditions(ctx context.Context, req *mcp.CallToolRequest, args struct{}) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	apps, err := s.getArgocdApplications(ctx, ApplicationFilter{})
+	if err != nil {
+		return toolError(err)
+	}
+
+	result := &FleetConditions{
+		TotalApplications: len(apps.Items),
+		Conditions:        []ConditionGroup{},
+	}
+	groups := map[string]*ConditionGroup{}
+	for _, app := range apps.Items {
+		if len(app.Status.Conditions) > 0 {
+			result.AffectedApplications++
+		}
+		for _, cond := range app.Status.Conditions {
+			group, ok := groups[cond.Type]
+			if !ok {
+				group = &ConditionGroup{Type: cond.Type}
+				groups[cond.Type] = group
+			}
+			group.Applications = append(group.Applications, ConditionOccurrence{
+				Name:    app.Metadata.Name,
+				Message: cond.Message,
+			})
+		}
+	}
+
+	for _, group := range groups {
+		sort.Slice(group.Applications, func(i, j int) bool {
+			return group.Applications[i].Name < group.Applications[j].Name
+		})
+		group.Count = len(group.Applications)
+		result.Conditions = append(result.Conditions, *group)
+	}
+	sort.Slice(result.Conditions, func(i, j int) bool {
+		a, b := result.Conditions[i], result.Conditions[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Type < b.Type
+	})
+
+	return toolJSON(result)
+}
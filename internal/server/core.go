@@ -0,0 +1,330 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// coreModeEnv switches the server to ArgoCD core mode, where there is no
+	// API server and Application resources are read from Kubernetes directly
+	coreModeEnv = "ARGOCD_CORE"
+
+	// defaultCoreNamespace is where Applications are read from when the
+	// kubeconfig context doesn't name a namespace
+	defaultCoreNamespace = "argocd"
+
+	// serviceAccountDir holds the credentials Kubernetes mounts into pods
+	serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+)
+
+// coreTools are the tools that work in core mode. The rest need the ArgoCD
+// API server and aren't registered.
+var coreTools = map[string]bool{
+	"list_applications": true,
+	"get_server_status": true,
+}
+
+// kubeconfig is the subset of a kubeconfig file needed to reach the cluster
+// of its current context
+type kubeconfig struct {
+	CurrentContext string `json:"current-context"`
+	Clusters       []struct {
+		Name    string `json:"name"`
+		Cluster struct {
+			Server                   string `json:"server"`
+			CertificateAuthority     string `json:"certificate-authority"`
+			CertificateAuthorityData []byte `json:"certificate-authority-data"`
+			InsecureSkipTLSVerify    bool   `json:"insecure-skip-tls-verify"`
+			TLSServerName            string `json:"tls-server-name"`
+		} `json:"cluster"`
+	} `json:"clusters"`
+	Contexts []struct {
+		Name    string `json:"name"`
+		Context struct {
+			Cluster   string `json:"cluster"`
+			User      string `json:"user"`
+			Namespace string `json:"namespace"`
+		} `json:"context"`
+	} `json:"contexts"`
+	Users []struct {
+		Name string `json:"name"`
+		User struct {
+			Token                 string          `json:"token"`
+			TokenFile             string          `json:"tokenFile"`
+			ClientCertificate     string          `json:"client-certificate"`
+			ClientCertificateData []byte          `json:"client-certificate-data"`
+			ClientKey             string          `json:"client-key"`
+			ClientKeyData         []byte          `json:"client-key-data"`
+			Exec                  json.RawMessage `json:"exec"`
+			AuthProvider          json.RawMessage `json:"auth-provider"`
+		} `json:"user"`
+	} `json:"users"`
+}
+
+// kubeClient reads ArgoCD Application resources from the Kubernetes API
+type kubeClient struct {
+	serverURL  string
+	namespace  string
+	token      string
+	tokenFile  string // re-read per request, as service account tokens rotate
+	httpClient *http.Client
+}
+
+// kubeconfigPath returns the kubeconfig to use: the first KUBECONFIG entry,
+// or ~/.kube/config
+func kubeconfigPath() string {
+	if paths := filepath.SplitList(os.Getenv("KUBECONFIG")); len(paths) > 0 && paths[0] != "" {
+		return paths[0]
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".kube", "config")
+}
+
+// loadKubeClient builds the core mode client from the kubeconfig, falling
+// back to the pod's service account when running in a cluster without one
+func loadKubeClient(clientCfg httpClientConfig) (*kubeClient, error) {
+	path := kubeconfigPath()
+	if _, err := os.Stat(path); err != nil && os.Getenv("KUBERNETES_SERVICE_HOST") != "" {
+		return inClusterKubeClient(clientCfg)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read kubeconfig: %w", err)
+	}
+	var cfg kubeconfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse kubeconfig %s: %w", path, err)
+	}
+	return cfg.client(filepath.Dir(path), clientCfg)
+}
+
+// client builds a kubeClient for the current context. Relative file paths
+// are resolved against dir, the kubeconfig's directory.
+func (c *kubeconfig) client(dir string, clientCfg httpClientConfig) (*kubeClient, error) {
+	resolve := func(path string) string {
+		if path == "" || filepath.IsAbs(path) {
+			return path
+		}
+		return filepath.Join(dir, path)
+	}
+
+	if c.CurrentContext == "" {
+		return nil, fmt.Errorf("kubeconfig has no current-context")
+	}
+	var clusterName, userName, namespace string
+	found := false
+	for _, kctx := range c.Contexts {
+		if kctx.Name == c.CurrentContext {
+			clusterName, userName, namespace = kctx.Context.Cluster, kctx.Context.User, kctx.Context.Namespace
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("kubeconfig context %q not found", c.CurrentContext)
+	}
+
+	client := &kubeClient{namespace: firstNonEmpty(namespace, defaultCoreNamespace)}
+	tlsCfg := &tls.Config{}
+	found = false
+	for _, cluster := range c.Clusters {
+		if cluster.Name != clusterName {
+			continue
+		}
+		found = true
+		client.serverURL = strings.TrimSuffix(cluster.Cluster.Server, "/")
+		tlsCfg.InsecureSkipVerify = cluster.Cluster.InsecureSkipTLSVerify
+		tlsCfg.ServerName = cluster.Cluster.TLSServerName
+
+		caData := cluster.Cluster.CertificateAuthorityData
+		if len(caData) == 0 && cluster.Cluster.CertificateAuthority != "" {
+			data, err := os.ReadFile(resolve(cluster.Cluster.CertificateAuthority))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read cluster CA: %w", err)
+			}
+			caData = data
+		}
+		if len(caData) > 0 {
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(caData) {
+				return nil, fmt.Errorf("cluster %q CA contains no PEM certificates", clusterName)
+			}
+			tlsCfg.RootCAs = pool
+		}
+	}
+	if !found || client.serverURL == "" {
+		return nil, fmt.Errorf("kubeconfig cluster %q not found or has no server", clusterName)
+	}
+
+	for _, user := range c.Users {
+		if user.Name != userName {
+			continue
+		}
+		u := user.User
+		if len(u.Exec) > 0 || len(u.AuthProvider) > 0 {
+			return nil, fmt.Errorf("kubeconfig user %q uses an exec or auth-provider plugin, which core mode doesn't support; use a token or client certificate", userName)
+		}
+		client.token = u.Token
+		client.tokenFile = resolve(u.TokenFile)
+
+		certData, keyData := u.ClientCertificateData, u.ClientKeyData
+		if len(certData) == 0 && u.ClientCertificate != "" {
+			data, err := os.ReadFile(resolve(u.ClientCertificate))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read client certificate: %w", err)
+			}
+			certData = data
+		}
+		if len(keyData) == 0 && u.ClientKey != "" {
+			data, err := os.ReadFile(resolve(u.ClientKey))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read client key: %w", err)
+			}
+			keyData = data
+		}
+		if len(certData) > 0 {
+			cert, err := tls.X509KeyPair(certData, keyData)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load client certificate of user %q: %w", userName, err)
+			}
+			tlsCfg.Certificates = []tls.Certificate{cert}
+		}
+	}
+
+	client.httpClient = &http.Client{
+		Transport: clientCfg.newTransport(tlsCfg),
+		Timeout:   clientCfg.timeout,
+	}
+	return client, nil
+}
+
+// inClusterKubeClient builds the core mode client from the service account
+// Kubernetes mounts into the pod
+func inClusterKubeClient(clientCfg httpClientConfig) (*kubeClient, error) {
+	ca, err := os.ReadFile(filepath.Join(serviceAccountDir, "ca.crt"))
+	if err != nil {
+		return nil, fmt.Errorf("no kubeconfig found and failed to read the service account CA: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("service account CA contains no PEM certificates")
+	}
+
+	namespace := defaultCoreNamespace
+	if data, err := os.ReadFile(filepath.Join(serviceAccountDir, "namespace")); err == nil {
+		namespace = firstNonEmpty(strings.TrimSpace(string(data)), defaultCoreNamespace)
+	}
+
+	host := net.JoinHostPort(os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT"))
+	return &kubeClient{
+		serverURL: "https://" + host,
+		namespace: namespace,
+		tokenFile: filepath.Join(serviceAccountDir, "token"),
+		httpClient: &http.Client{
+			Transport: clientCfg.newTransport(&tls.Config{RootCAs: pool}),
+			Timeout:   clientCfg.timeout,
+		},
+	}, nil
+}
+
+// get performs an authenticated GET against the Kubernetes API and decodes
+// the JSON response into out
+func (k *kubeClient) get(ctx context.Context, path string, query url.Values, out any, maxBytes int64) error {
+	reqURL := k.serverURL + path
+	if len(query) > 0 {
+		reqURL += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	token := k.token
+	if k.tokenFile != "" {
+		if token, err = readTokenFile(k.tokenFile); err != nil {
+			return err
+		}
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := k.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Kubernetes Status errors carry a message and code like ArgoCD's
+	if resp.StatusCode != http.StatusOK {
+		return newAPIError(resp)
+	}
+
+	body, err := readLimited(resp.Body, maxBytes)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return nil
+}
+
+// applicationsPath is the Kubernetes API path of the Application resources
+func (k *kubeClient) applicationsPath() string {
+	return "/apis/argoproj.io/v1alpha1/namespaces/" + url.PathEscape(k.namespace) + "/applications"
+}
+
+// listApplications lists the Application resources, applying the filter
+// fields ArgoCD's API server would otherwise apply: label selector, project
+// and source repository
+func (k *kubeClient) listApplications(ctx context.Context, filter ApplicationFilter, maxBytes int64) (*ArgocdApplicationList, error) {
+	query := url.Values{}
+	if filter.Selector != "" {
+		query.Set("labelSelector", filter.Selector)
+	}
+	var list ArgocdApplicationList
+	if err := k.get(ctx, k.applicationsPath(), query, &list, maxBytes); err != nil {
+		return nil, err
+	}
+
+	if filter.Project == "" && filter.Repo == "" {
+		return &list, nil
+	}
+	matched := list.Items[:0]
+	for i := range list.Items {
+		app := &list.Items[i]
+		if filter.Project != "" && app.Spec.Project != filter.Project {
+			continue
+		}
+		if filter.Repo != "" && !usesRepo(app, filter.Repo) {
+			continue
+		}
+		matched = append(matched, *app)
+	}
+	list.Items = matched
+	return &list, nil
+}
+
+// ping checks that the Application resources can be listed
+func (k *kubeClient) ping(ctx context.Context, maxBytes int64) error {
+	var list ArgocdApplicationList
+	return k.get(ctx, k.applicationsPath(), url.Values{"limit": {"1"}}, &list, maxBytes)
+}
@@ -0,0 +1,162 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestCoreModeListApplications(t *testing.T) {
+	var gotAuth, gotQuery string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/apis/argoproj.io/v1alpha1/namespaces/gitops/applications" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"kind":"Status","message":"not found","code":404}`))
+			return
+		}
+		gotAuth, gotQuery = r.Header.Get("Authorization"), r.URL.RawQuery
+		w.Write([]byte(`{"apiVersion":"argoproj.io/v1alpha1","kind":"ApplicationList","items":[
+			{"metadata":{"name":"web"},"spec":{"project":"team-a","source":{"repoURL":"https://github.com/org/web.git"}}},
+			{"metadata":{"name":"api"},"spec":{"project":"team-b","sources":[{"repoURL":"https://github.com/org/api"}]}}
+		]}`))
+	}))
+	defer ts.Close()
+
+	dir := t.TempDir()
+	kubeconfig := `apiVersion: v1
+kind: Config
+current-context: dev
+contexts:
+- name: dev
+  context: {cluster: dev-cluster, user: dev-user, namespace: gitops}
+clusters:
+- name: dev-cluster
+  cluster: {server: "` + ts.URL + `"}
+users:
+- name: dev-user
+  user: {tokenFile: token}
+`
+	if err := os.WriteFile(filepath.Join(dir, "config"), []byte(kubeconfig), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "token"), []byte("k8s-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("KUBECONFIG", filepath.Join(dir, "config"))
+
+	client, err := loadKubeClient(httpClientConfig{})
+	if err != nil {
+		t.Fatalf("loadKubeClient: %v", err)
+	}
+
+	list, err := client.listApplications(context.Background(), ApplicationFilter{Selector: "team=a"}, defaultMaxResponseBytes)
+	if err != nil {
+		t.Fatalf("listApplications: %v", err)
+	}
+	if len(list.Items) != 2 {
+		t.Errorf("got %d applications, want 2", len(list.Items))
+	}
+	if gotAuth != "Bearer k8s-token" {
+		t.Errorf("Authorization = %q, want the token from the relative tokenFile", gotAuth)
+	}
+	if gotQuery != "labelSelector=team%3Da" {
+		t.Errorf("query = %q, want the selector as labelSelector", gotQuery)
+	}
+
+	for _, tt := range []struct {
+		filter ApplicationFilter
+		want   string
+	}{
+		{ApplicationFilter{Project: "team-b"}, "api"},
+		{ApplicationFilter{Repo: "https://github.com/org/web"}, "web"},
+		{ApplicationFilter{Repo: "https://github.com/org/api.git"}, "api"},
+	} {
+		list, err := client.listApplications(context.Background(), tt.filter, defaultMaxResponseBytes)
+		if err != nil {
+			t.Fatalf("listApplications(%+v): %v", tt.filter, err)
+		}
+		if len(list.Items) != 1 || list.Items[0].Metadata.Name != tt.want {
+			t.Errorf("listApplications(%+v) = %v, want only %s", tt.filter, list.Items, tt.want)
+		}
+	}
+}
+
+func TestCoreModeRejectsExecPlugins(t *testing.T) {
+	dir := t.TempDir()
+	kubeconfig := `current-context: eks
+contexts:
+- name: eks
+  context: {cluster: eks, user: eks}
+clusters:
+- name: eks
+  cluster: {server: "https://example.eks.amazonaws.com"}
+users:
+- name: eks
+  user:
+    exec: {command: aws, args: [eks, get-token]}
+`
+	if err := os.WriteFile(filepath.Join(dir, "config"), []byte(kubeconfig), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("KUBECONFIG", filepath.Join(dir, "config"))
+
+	_, err := loadKubeClient(httpClientConfig{})
+	if err == nil || !strings.Contains(err.Error(), "exec") {
+		t.Errorf("loadKubeClient error = %v, want an unsupported exec plugin error", err)
+	}
+}
+
+func TestCoreModeApplicationsResource(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/apis/argoproj.io/v1alpha1/namespaces/argocd/applications" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"kind":"Status","message":"not found","code":404}`))
+			return
+		}
+		w.Write([]byte(`{"kind":"ApplicationList","items":[
+			{"metadata":{"name":"web"},"status":{"health":{"status":"Healthy"}}},
+			{"metadata":{"name":"api"},"status":{"health":{"status":"Degraded"}}}
+		]}`))
+	}))
+	defer ts.Close()
+
+	dir := t.TempDir()
+	kubeconfig := `current-context: dev
+contexts:
+- name: dev
+  context: {cluster: dev, user: dev}
+clusters:
+- name: dev
+  cluster: {server: "` + ts.URL + `"}
+users:
+- name: dev
+  user: {token: k8s-token}
+`
+	if err := os.WriteFile(filepath.Join(dir, "config"), []byte(kubeconfig), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("KUBECONFIG", filepath.Join(dir, "config"))
+	t.Setenv(coreModeEnv, "true")
+	session := connectTestClient(t)
+
+	for uri, want := range map[string]string{
+		"argocd://applications?view=names":                               "[\n  \"web\",\n  \"api\"\n]",
+		"argocd://applications?format=ndjson&view=names":                 "\"web\"\n\"api\"\n",
+		"argocd://applications?format=ndjson&view=names&health=degraded": "\"api\"\n",
+	} {
+		res, err := session.ReadResource(context.Background(), &mcp.ReadResourceParams{URI: uri})
+		if err != nil {
+			t.Errorf("ReadResource(%s) error: %v", uri, err)
+			continue
+		}
+		if got := res.Contents[0].Text; got != want {
+			t.Errorf("ReadResource(%s) = %q, want %q", uri, got, want)
+		}
+	}
+}
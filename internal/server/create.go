@@ -0,0 +1,209 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// CreateApplicationArgs are the arguments for the create_application tool
+type CreateApplicationArgs struct {
+	Name                 string `json:"name" jsonschema:"the application name"`
+	Project              string `json:"project,omitempty" jsonschema:"the AppProject to create the application in (default: ARGOCD_DEFAULT_PROJECT, or default)"`
+	RepoURL              string `json:"repo_url" jsonschema:"the Git repository URL of the source"`
+	Path                 string `json:"path" jsonschema:"the directory within the repository holding the manifests"`
+	TargetRevision       string `json:"target_revision,omitempty" jsonschema:"the branch, tag or commit to deploy (default HEAD)"`
+	DestinationServer    string `json:"destination_server,omitempty" jsonschema:"the destination cluster API server URL; required unless destination_name is set"`
+	DestinationName      string `json:"destination_name,omitempty" jsonschema:"the destination cluster name, as an alternative to destination_server"`
+	DestinationNamespace string `json:"destination_namespace" jsonschema:"the namespace to deploy into"`
+	AutoSync             bool   `json:"auto_sync,omitempty" jsonschema:"enable automated sync with prune and self-heal"`
+}
+
+// ValidateApplicationArgs are the arguments for the validate_application
+// tool. They mirror CreateApplicationArgs with every field optional, so that
+// missing fields are reported as validation errors instead of being rejected
+// by the input schema.
+type ValidateApplicationArgs struct {
+	Name                 string `json:"name,omitempty" jsonschema:"the application name"`
+	Project              string `json:"project,omitempty" jsonschema:"the AppProject to create the application in (default: ARGOCD_DEFAULT_PROJECT, or default)"`
+	RepoURL              string `json:"repo_url,omitempty" jsonschema:"the Git repository URL of the source"`
+	Path                 string `json:"path,omitempty" jsonschema:"the directory within the repository holding the manifests"`
+	TargetRevision       string `json:"target_revision,omitempty" jsonschema:"the branch, tag or commit to deploy (default HEAD)"`
+	DestinationServer    string `json:"destination_server,omitempty" jsonschema:"the destination cluster API server URL; required unless destination_name is set"`
+	DestinationName      string `json:"destination_name,omitempty" jsonschema:"the destination cluster name, as an alternative to destination_server"`
+	DestinationNamespace string `json:"destination_namespace,omitempty" jsonschema:"the namespace to deploy into"`
+	AutoSync             bool   `json:"auto_sync,omitempty" jsonschema:"enable automated sync with prune and self-heal"`
+}
+
+// problems lists everything wrong with the arguments that can be checked
+// without calling ArgoCD
+func (a *CreateApplicationArgs) problems() []string {
+	var missing, problems []string
+	if a.Name == "" {
+		missing = append(missing, "name")
+	}
+	if a.RepoURL == "" {
+		missing = append(missing, "repo_url")
+	}
+	if a.Path == "" {
+		missing = append(missing, "path")
+	}
+	if a.DestinationServer == "" && a.DestinationName == "" {
+		missing = append(missing, "destination_server or destination_name")
+	}
+	if a.DestinationNamespace == "" {
+		missing = append(missing, "destination_namespace")
+	}
+	if len(missing) > 0 {
+		problems = append(problems, fmt.Sprintf("missing required fields: %s", strings.Join(missing, ", ")))
+	}
+	if a.DestinationServer != "" && a.DestinationName != "" {
+		problems = append(problems, "set only one of destination_server and destination_name")
+	}
+	return problems
+}
+
+// validate checks the fields ArgoCD needs before anything is sent
+func (a *CreateApplicationArgs) validate() error {
+	if problems := a.problems(); len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// applicationManifest builds the Application object to POST to ArgoCD
+func (a *CreateApplicationArgs) applicationManifest() map[string]any {
+	destination := map[string]any{"namespace": a.DestinationNamespace}
+	if a.DestinationServer != "" {
+		destination["server"] = a.DestinationServer
+	} else {
+		destination["name"] = a.DestinationName
+	}
+
+	spec := map[string]any{
+		"project": firstNonEmpty(a.Project, "default"),
+		"source": map[string]any{
+			"repoURL":        a.RepoURL,
+			"path":           a.Path,
+			"targetRevision": firstNonEmpty(a.TargetRevision, "HEAD"),
+		},
+		"destination": destination,
+	}
+	if a.AutoSync {
+		spec["syncPolicy"] = map[string]any{
+			"automated": map[string]any{"prune": true, "selfHeal": true},
+		}
+	}
+
+	return map[string]any{
+		"metadata": map[string]any{"name": a.Name},
+		"spec":     spec,
+	}
+}
+
+// applicationProject resolves the project a new application goes in: the
+// project argument, then ARGOCD_DEFAULT_PROJECT, then "default"
+func (s *MCPServer) applicationProject(project string) string {
+	return firstNonEmpty(project, s.defaultProject, "default")
+}
+
+func (s *MCPServer) handleCreateApplication(ctx context.Context, req *mcp.CallToolRequest, args CreateApplicationArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	args.Project = s.applicationProject(args.Project)
+	if err := args.validate(); err != nil {
+		return toolError(err)
+	}
+
+	var app ArgocdApplication
+	if err := s.argocdDo(ctx, "POST", "/api/v1/applications", nil, args.applicationManifest(), &app); err != nil {
+		if errors.Is(err, ErrConflict) {
+			err = fmt.Errorf("application %q already exists with a different spec: %w", args.Name, err)
+		}
+		return toolError(err)
+	}
+
+	return toolJSON(ApplicationStatus{
+		Name:         app.Metadata.Name,
+		SyncStatus:   app.Status.Sync.Status,
+		HealthStatus: app.Status.Health.Status,
+		Revision:     app.Status.Sync.Revision,
+	})
+}
+
+// ApplicationValidation is the result of the validate_application tool
+type ApplicationValidation struct {
+	Valid    bool     `json:"valid"`
+	Errors   []string `json:"errors"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// validateApplication checks a spec against what create_application would
+// send, the target project's rules and existing applications. ArgoCD has no
+// dry-run for creating applications, so nothing is sent that would create one.
+func (s *MCPServer) validateApplication(ctx context.Context, args CreateApplicationArgs) (*ApplicationValidation, error) {
+	result := &ApplicationValidation{Errors: args.problems()}
+	if len(result.Errors) > 0 {
+		return result, nil
+	}
+
+	projectName := s.applicationProject(args.Project)
+	project, err := s.getProject(ctx, projectName)
+	switch {
+	case errors.Is(err, ErrNotFound):
+		result.Errors = append(result.Errors, fmt.Sprintf("project %q does not exist", projectName))
+	case err != nil:
+		return nil, fmt.Errorf("failed to get project %q: %w", projectName, err)
+	default:
+		if !sourcePermitted(project.Spec.SourceRepos, args.RepoURL) {
+			result.Errors = append(result.Errors, fmt.Sprintf("repo_url %q is not permitted by project %q's sourceRepos", args.RepoURL, projectName))
+		}
+		if !destinationPermitted(project.Spec.Destinations, args.DestinationServer, args.DestinationName, args.DestinationNamespace) {
+			result.Errors = append(result.Errors, fmt.Sprintf("destination %s/%s is not permitted by project %q's destinations",
+				firstNonEmpty(args.DestinationServer, args.DestinationName), args.DestinationNamespace, projectName))
+		}
+	}
+
+	_, err = s.getApplication(ctx, args.Name)
+	switch {
+	case err == nil:
+		result.Errors = append(result.Errors, fmt.Sprintf("application %q already exists", args.Name))
+	case errors.Is(err, ErrNotFound), errors.Is(err, ErrForbidden):
+		// ArgoCD answers 403 for applications that don't exist
+	default:
+		return nil, fmt.Errorf("failed to check for an existing application: %w", err)
+	}
+
+	var repos RepositoryList
+	if err := s.argocdGet(ctx, "/api/v1/repositories", nil, &repos); err == nil {
+		registered := false
+		for _, repo := range repos.Items {
+			if normalizeRepoURL(repo.Repo) == normalizeRepoURL(args.RepoURL) {
+				registered = true
+				break
+			}
+		}
+		if !registered {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("repo_url %q is not registered in ArgoCD; this only works for public repositories", args.RepoURL))
+		}
+	}
+
+	if result.Errors == nil {
+		result.Errors = []string{}
+	}
+	result.Valid = len(result.Errors) == 0
+	return result, nil
+}
+
+func (s *MCPServer) handleValidateApplication(ctx context.Context, req *mcp.CallToolRequest, args ValidateApplicationArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	result, err := s.validateApplication(ctx, CreateApplicationArgs(args))
+	if err != nil {
+		return toolError(err)
+	}
+	return toolJSON(result)
+}
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// defaultRequestTimeout bounds each MCP request when MCP_REQUEST_TIMEOUT is unset
+	defaultRequestTimeout = 2 * time.Minute
+
+	requestTimeoutEnv = "MCP_REQUEST_TIMEOUT"
+
+	// defaultCallTimeout bounds each ArgoCD API call, retries included, when
+	// ARGOCD_REQUEST_TIMEOUT is unset
+	defaultCallTimeout = time.Minute
+)
+
+// selfTimedTools wait on ArgoCD for as long as the caller asks and bound
+// themselves with their own timeout argument, so the request deadline
+// doesn't apply to them
+var selfTimedTools = map[string]bool{
+	"resync_application": true,
+	"sync_and_report":    true,
+	"wait_for_sync":      true,
+	"watch_applications": true,
+}
+
+// maxToolTimeout caps the timeout_seconds of selfTimedTools, since the
+// request deadline doesn't bound them
+const maxToolTimeout = 30 * time.Minute
+
+// toolTimeout returns how long a self-timed tool waits: seconds when given,
+// capped at maxToolTimeout, and defaultValue otherwise
+func toolTimeout(seconds int, defaultValue time.Duration) time.Duration {
+	switch {
+	case seconds <= 0:
+		return defaultValue
+	case seconds >= int(maxToolTimeout/time.Second):
+		return maxToolTimeout
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// loadRequestTimeout reads the per-request deadline from MCP_REQUEST_TIMEOUT.
+// Zero or a negative value disables the deadline.
+func loadRequestTimeout() time.Duration {
+	return getEnvDuration(requestTimeoutEnv, defaultRequestTimeout)
+}
+
+// deadlineMiddleware gives every incoming request a deadline so a hung
+// ArgoCD call can't hold a transport worker forever
+func (s *MCPServer) deadlineMiddleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if s.requestTimeout <= 0 {
+			return next(ctx, method, req)
+		}
+		if call, ok := req.(*mcp.CallToolRequest); ok && call.Params != nil && selfTimedTools[call.Params.Name] {
+			return next(ctx, method, req)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, s.requestTimeout)
+		defer cancel()
+		return next(ctx, method, req)
+	}
+}
+
+// callContext derives the context for a single ArgoCD API call. Unlike the
+// HTTP client timeout, which applies per attempt, it bounds the whole call
+// including retries and re-login, and it still applies when the incoming
+// context has no deadline. Cancelling ctx aborts the call as before.
+func (s *MCPServer) callContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.callTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, s.callTimeout)
+}
@@ -0,0 +1,43 @@
+package server
+
+import "testing"
+
+func TestApplicationProject(t *testing.T) {
+	tests := []struct {
+		defaultProject string
+		project        string
+		want           string
+	}{
+		{"", "", "default"},
+		{"payments", "", "payments"},
+		{"payments", "billing", "billing"},
+		{"", "billing", "billing"},
+	}
+	for _, tt := range tests {
+		s := &MCPServer{defaultProject: tt.defaultProject}
+		if got := s.applicationProject(tt.project); got != tt.want {
+			t.Errorf("applicationProject(%q) with default %q = %q, want %q", tt.project, tt.defaultProject, got, tt.want)
+		}
+	}
+}
+
+func TestGetApplicationQuery(t *testing.T) {
+	s := &MCPServer{defaultProject: "payments", defaultAppNamespace: "argocd-apps"}
+
+	// The default project must not become a filter
+	query := s.getApplicationQuery(GetApplicationArgs{Name: "web"})
+	if got := query.Get("appNamespace"); got != "argocd-apps" {
+		t.Errorf("appNamespace = %q, want argocd-apps", got)
+	}
+	if query.Has("project") {
+		t.Errorf("project = %q, want unset", query.Get("project"))
+	}
+
+	query = s.getApplicationQuery(GetApplicationArgs{Name: "web", Namespace: "team-a", Project: "billing"})
+	if got := query.Get("appNamespace"); got != "team-a" {
+		t.Errorf("appNamespace = %q, want team-a", got)
+	}
+	if got := query.Get("project"); got != "billing" {
+		t.Errorf("project = %q, want billing", got)
+	}
+}
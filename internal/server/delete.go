@@ -0,0 +1,113 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// propagationPolicies are the deletion propagation policies ArgoCD accepts
+var propagationPolicies = map[string]bool{
+	"foreground": true,
+	"background": true,
+	"orphan":     true,
+}
+
+// DeleteApplicationArgs are the arguments for the delete_application tool
+type DeleteApplicationArgs struct {
+	Name              string `json:"name" jsonschema:"the application name"`
+	Cascade           *bool  `json:"cascade,omitempty" jsonschema:"also delete the application's resources from the cluster (ArgoCD default true)"`
+	PropagationPolicy string `json:"propagation_policy,omitempty" jsonschema:"how dependents are deleted: foreground, background or orphan"`
+}
+
+// DeleteApplicationResourceArgs are the arguments for the
+// delete_application_resource tool
+type DeleteApplicationResourceArgs struct {
+	Name         string `json:"name" jsonschema:"the application name"`
+	Group        string `json:"group,omitempty" jsonschema:"the resource's API group, e.g. apps; empty for core resources"`
+	Version      string `json:"version" jsonschema:"the resource's API version, e.g. v1"`
+	Kind         string `json:"kind" jsonschema:"the resource kind, e.g. Job"`
+	Namespace    string `json:"namespace,omitempty" jsonschema:"the resource namespace; empty for cluster-scoped resources"`
+	ResourceName string `json:"resource_name" jsonschema:"the resource name"`
+	Force        bool   `json:"force,omitempty" jsonschema:"delete immediately, removing finalizers that keep the resource stuck in deletion"`
+}
+
+func (s *MCPServer) handleDeleteApplication(ctx context.Context, req *mcp.CallToolRequest, args DeleteApplicationArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	if args.Name == "" {
+		return toolError(fmt.Errorf("name is required"))
+	}
+	if args.PropagationPolicy != "" && !propagationPolicies[args.PropagationPolicy] {
+		return toolError(fmt.Errorf("invalid propagation_policy %q: must be foreground, background or orphan", args.PropagationPolicy))
+	}
+
+	query := url.Values{}
+	if args.Cascade != nil {
+		query.Set("cascade", strconv.FormatBool(*args.Cascade))
+	}
+	if args.PropagationPolicy != "" {
+		query.Set("propagationPolicy", args.PropagationPolicy)
+	}
+
+	if err := s.argocdDo(ctx, "DELETE", applicationPath(args.Name), query, nil, nil); err != nil {
+		return toolError(err)
+	}
+
+	message := fmt.Sprintf("Application %q deleted", args.Name)
+	if args.Cascade != nil && !*args.Cascade {
+		message += "; its resources were left running in the cluster"
+	}
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: message},
+		},
+	}, nil, nil
+}
+
+func (s *MCPServer) handleDeleteApplicationResource(ctx context.Context, req *mcp.CallToolRequest, args DeleteApplicationResourceArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	if args.Name == "" {
+		return toolError(fmt.Errorf("name is required"))
+	}
+	if args.Version == "" {
+		return toolError(fmt.Errorf("version is required"))
+	}
+	if args.Kind == "" {
+		return toolError(fmt.Errorf("kind is required"))
+	}
+	if args.ResourceName == "" {
+		return toolError(fmt.Errorf("resource_name is required"))
+	}
+
+	query := url.Values{}
+	query.Set("group", args.Group)
+	query.Set("version", args.Version)
+	query.Set("kind", args.Kind)
+	query.Set("namespace", args.Namespace)
+	query.Set("resourceName", args.ResourceName)
+	if args.Force {
+		query.Set("force", "true")
+	}
+
+	resource := resourceLabel(args.Kind, args.Namespace, args.ResourceName)
+	if err := s.argocdDo(ctx, "DELETE", applicationPath(args.Name)+"/resource", query, nil, nil); err != nil {
+		// ArgoCD answers 404 both for an unknown application and for a
+		// resource that isn't (or is no longer) in its resource tree
+		if errors.Is(err, ErrNotFound) {
+			err = fmt.Errorf("%s not found in application %q; it may already be deleted: %w", resource, args.Name, err)
+		}
+		return toolError(err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: fmt.Sprintf("Deletion of %s in application %q requested", resource, args.Name)},
+		},
+	}, nil, nil
+}
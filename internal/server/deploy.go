@@ -0,0 +1,91 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Annotations recording why and when a deploy happened
+const (
+	deployReasonAnnotation = "deploy.reason"
+	deployTimeAnnotation   = "deploy.timestamp"
+)
+
+// AnnotateDeployArgs are the arguments for the annotate_deploy tool
+type AnnotateDeployArgs struct {
+	Name   string `json:"name" jsonschema:"the application name"`
+	Reason string `json:"reason" jsonschema:"why this deploy is happening, recorded on the application for auditing"`
+	Sync   bool   `json:"sync,omitempty" jsonschema:"trigger a sync right after recording the reason"`
+}
+
+// AnnotateDeployResult is the result of the annotate_deploy tool
+type AnnotateDeployResult struct {
+	Name        string            `json:"name"`
+	Annotations map[string]string `json:"annotations"`
+	Synced      bool              `json:"sync_triggered"`
+	SyncError   string            `json:"sync_error,omitempty"`
+}
+
+// patchApplication applies a patch to an application's spec or metadata.
+// patchType is "merge" or "json".
+func (s *MCPServer) patchApplication(ctx context.Context, name string, patch any, patchType string) (*ArgocdApplication, error) {
+	patchJSON, err := json.Marshal(patch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal patch: %w", err)
+	}
+
+	body := map[string]string{
+		"name":      name,
+		"patch":     string(patchJSON),
+		"patchType": patchType,
+	}
+
+	var app ArgocdApplication
+	if err := s.argocdDo(ctx, "PATCH", applicationPath(name), nil, body, &app); err != nil {
+		return nil, err
+	}
+	return &app, nil
+}
+
+func (s *MCPServer) handleAnnotateDeploy(ctx context.Context, req *mcp.CallToolRequest, args AnnotateDeployArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	reason := strings.TrimSpace(args.Reason)
+	if args.Name == "" || reason == "" {
+		return toolError(fmt.Errorf("name and reason are required"))
+	}
+
+	// A merge patch only touches the keys it names, so existing annotations are kept
+	patch := map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]string{
+				deployReasonAnnotation: reason,
+				deployTimeAnnotation:   time.Now().UTC().Format(time.RFC3339),
+			},
+		},
+	}
+	app, err := s.patchApplication(ctx, args.Name, patch, "merge")
+	if err != nil {
+		return toolError(err)
+	}
+
+	result := &AnnotateDeployResult{
+		Name:        app.Metadata.Name,
+		Annotations: app.Metadata.Annotations,
+	}
+
+	if args.Sync {
+		if _, err := s.syncApplication(ctx, SyncRequest{Name: args.Name}); err != nil {
+			result.SyncError = err.Error()
+		} else {
+			result.Synced = true
+		}
+	}
+
+	return toolJSON(result)
+}
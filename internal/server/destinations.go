@@ -0,0 +1,91 @@
+package server
+
+import (
+	"context"
+	"sort"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// DestinationUsage describes a unique deployment destination and the apps using it
+type DestinationUsage struct {
+	ClusterName  string   `json:"cluster_name,omitempty"`
+	Server       string   `json:"server"`
+	Namespace    string   `json:"namespace"`
+	AppCount     int      `json:"app_count"`
+	Applications []string `json:"applications"`
+}
+
+// DestinationsReport is the result of the list_destinations tool
+type DestinationsReport struct {
+	Destinations []DestinationUsage `json:"destinations"`
+	Warning      string             `json:"warning,omitempty"`
+}
+
+func (s *MCPServer) handleListDestinations(ctx context.Context, req *mcp.CallToolRequest, args struct{}) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	apps, err := s.getArgocdApplications(ctx, ApplicationFilter{})
+	if err != nil {
+		return toolError(err)
+	}
+
+	report := &DestinationsReport{Destinations: []DestinationUsage{}}
+
+	// Map cluster servers and names to each other so destinations read well
+	// regardless of which one the app specifies
+	namesByServer := map[string]string{}
+	serversByName := map[string]string{}
+	clusters, err := s.getClusters(ctx)
+	if err != nil {
+		report.Warning = "cluster names unavailable: " + err.Error()
+	} else {
+		for _, cluster := range clusters.Items {
+			namesByServer[cluster.Server] = cluster.Name
+			serversByName[cluster.Name] = cluster.Server
+		}
+	}
+
+	type destinationKey struct{ server, name, namespace string }
+	usage := map[destinationKey]*DestinationUsage{}
+	for _, app := range apps.Items {
+		dest := app.Spec.Destination
+		server, name := dest.Server, dest.Name
+		if server == "" {
+			server = serversByName[name]
+		}
+		if name == "" {
+			name = namesByServer[server]
+		}
+
+		key := destinationKey{server, name, dest.Namespace}
+		entry, ok := usage[key]
+		if !ok {
+			entry = &DestinationUsage{
+				ClusterName: name,
+				Server:      server,
+				Namespace:   dest.Namespace,
+			}
+			usage[key] = entry
+		}
+		entry.AppCount++
+		entry.Applications = append(entry.Applications, app.Metadata.Name)
+	}
+
+	for _, entry := range usage {
+		sort.Strings(entry.Applications)
+		report.Destinations = append(report.Destinations, *entry)
+	}
+	sort.Slice(report.Destinations, func(i, j int) bool {
+		a, b := report.Destinations[i], report.Destinations[j]
+		if a.AppCount != b.AppCount {
+			return a.AppCount > b.AppCount
+		}
+		if a.Server != b.Server {
+			return a.Server < b.Server
+		}
+		return a.Namespace < b.Namespace
+	})
+
+	return toolJSON(report)
+}
@@ -0,0 +1,206 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"sigs.k8s.io/yaml"
+)
+
+// specDefaults are spec fields whose ArgoCD default is equivalent to leaving
+// them unset, keyed by their dotted path
+var specDefaults = map[string]any{
+	"project":               "default",
+	"source.targetRevision": "HEAD",
+}
+
+// unorderedLists are spec lists that ArgoCD treats as sets, keyed by their
+// dotted path. Other lists keep their order: later Helm valueFiles override
+// earlier ones, for example.
+var unorderedLists = map[string]bool{
+	"syncPolicy.syncOptions": true,
+	"finalizers":             true,
+}
+
+// DriftArgs are the arguments for the check_drift_from_git tool
+type DriftArgs struct {
+	Name string `json:"name" jsonschema:"the application name"`
+	YAML string `json:"yaml" jsonschema:"the Application manifest (or just its spec) as stored in Git"`
+}
+
+// SpecDifference is a single field that differs between Git and the live spec
+type SpecDifference struct {
+	Path string `json:"path"`
+	Git  any    `json:"git,omitempty"`
+	Live any    `json:"live,omitempty"`
+}
+
+// DriftReport is the result of the check_drift_from_git tool
+type DriftReport struct {
+	Application string           `json:"application"`
+	Drifted     bool             `json:"drifted"`
+	Differences []SpecDifference `json:"differences"`
+	Diff        string           `json:"diff,omitempty"`
+}
+
+// parseGitSpec extracts the application spec from a YAML manifest, accepting
+// either a full Application or a bare spec
+func parseGitSpec(manifest string) (map[string]any, error) {
+	var doc map[string]any
+	if err := yaml.Unmarshal([]byte(manifest), &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse yaml: %w", err)
+	}
+	if doc == nil {
+		return nil, fmt.Errorf("yaml is empty")
+	}
+	if kind, _ := doc["kind"].(string); kind != "" && kind != "Application" {
+		return nil, fmt.Errorf("expected an Application manifest, got kind %q", kind)
+	}
+	if spec, ok := doc["spec"].(map[string]any); ok {
+		return spec, nil
+	}
+	return doc, nil
+}
+
+// normalizeSpec strips defaulted and empty values and sorts set-like lists
+// so cosmetic differences don't show up as drift. Maps are kept even when
+// empty, since presence alone can matter: syncPolicy.automated: {} enables
+// auto-sync.
+func normalizeSpec(path string, v any) any {
+	if def, ok := specDefaults[path]; ok && reflect.DeepEqual(v, def) {
+		return nil
+	}
+
+	switch v := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, val := range v {
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+			if n := normalizeSpec(childPath, val); n != nil {
+				out[key] = n
+			}
+		}
+		return out
+	case []any:
+		var out []any
+		strs := true
+		for _, val := range v {
+			n := normalizeSpec(path, val)
+			if n == nil {
+				continue
+			}
+			if _, ok := n.(string); !ok {
+				strs = false
+			}
+			out = append(out, n)
+		}
+		if len(out) == 0 {
+			return nil
+		}
+		if strs && unorderedLists[path] {
+			sort.Slice(out, func(i, j int) bool { return out[i].(string) < out[j].(string) })
+		}
+		return out
+	case string:
+		if v == "" {
+			return nil
+		}
+		return v
+	case bool:
+		if !v {
+			return nil
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// compareSpecs records the paths where git and live differ
+func compareSpecs(path string, git, live any, diffs *[]SpecDifference) {
+	gitMap, gitIsMap := git.(map[string]any)
+	liveMap, liveIsMap := live.(map[string]any)
+	if gitIsMap && liveIsMap {
+		keys := map[string]bool{}
+		for key := range gitMap {
+			keys[key] = true
+		}
+		for key := range liveMap {
+			keys[key] = true
+		}
+		sorted := make([]string, 0, len(keys))
+		for key := range keys {
+			sorted = append(sorted, key)
+		}
+		sort.Strings(sorted)
+		for _, key := range sorted {
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+			compareSpecs(childPath, gitMap[key], liveMap[key], diffs)
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(git, live) {
+		*diffs = append(*diffs, SpecDifference{Path: path, Git: git, Live: live})
+	}
+}
+
+func (s *MCPServer) handleCheckDriftFromGit(ctx context.Context, req *mcp.CallToolRequest, args DriftArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	if args.Name == "" || strings.TrimSpace(args.YAML) == "" {
+		return toolError(fmt.Errorf("name and yaml are required"))
+	}
+
+	gitSpec, err := parseGitSpec(args.YAML)
+	if err != nil {
+		return toolError(err)
+	}
+
+	// Fetch the raw application so fields this server doesn't model are compared too
+	var live struct {
+		Spec map[string]any `json:"spec"`
+	}
+	if err := s.argocdGet(ctx, applicationPath(args.Name), nil, &live); err != nil {
+		return toolError(err)
+	}
+
+	// Round-trip the Git spec through JSON so numbers match the live decoding
+	data, err := json.Marshal(gitSpec)
+	if err != nil {
+		return toolError(fmt.Errorf("failed to encode git spec: %w", err))
+	}
+	var gitJSON map[string]any
+	if err := json.Unmarshal(data, &gitJSON); err != nil {
+		return toolError(fmt.Errorf("failed to decode git spec: %w", err))
+	}
+
+	normalizedGit := normalizeSpec("", gitJSON)
+	normalizedLive := normalizeSpec("", live.Spec)
+
+	report := &DriftReport{
+		Application: args.Name,
+		Differences: []SpecDifference{},
+	}
+	compareSpecs("", normalizedGit, normalizedLive, &report.Differences)
+	report.Drifted = len(report.Differences) > 0
+
+	if report.Drifted {
+		gitText, _ := json.MarshalIndent(normalizedGit, "", "  ")
+		liveText, _ := json.MarshalIndent(normalizedLive, "", "  ")
+		report.Diff = unifiedDiff("git", "live", string(gitText), string(liveText))
+	}
+
+	return toolJSON(report)
+}
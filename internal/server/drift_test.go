@@ -0,0 +1,50 @@
+package server
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeSpecDrift(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		git     map[string]any
+		live    map[string]any
+		drifted bool
+	}{
+		{
+			name:    "auto-sync disabled",
+			git:     map[string]any{"syncPolicy": map[string]any{"automated": map[string]any{}}},
+			live:    map[string]any{},
+			drifted: true,
+		},
+		{
+			name: "defaults and empty values",
+			git:  map[string]any{"project": "default", "source": map[string]any{"targetRevision": "HEAD", "path": "app"}},
+			live: map[string]any{"source": map[string]any{"path": "app", "chart": ""}},
+		},
+		{
+			name: "sync options reordered",
+			git:  map[string]any{"syncPolicy": map[string]any{"syncOptions": []any{"PruneLast=true", "CreateNamespace=true"}}},
+			live: map[string]any{"syncPolicy": map[string]any{"syncOptions": []any{"CreateNamespace=true", "PruneLast=true"}}},
+		},
+		{
+			name:    "value files reordered",
+			git:     map[string]any{"source": map[string]any{"helm": map[string]any{"valueFiles": []any{"base.yaml", "prod.yaml"}}}},
+			live:    map[string]any{"source": map[string]any{"helm": map[string]any{"valueFiles": []any{"prod.yaml", "base.yaml"}}}},
+			drifted: true,
+		},
+	} {
+		var diffs []SpecDifference
+		compareSpecs("", normalizeSpec("", tt.git), normalizeSpec("", tt.live), &diffs)
+		if got := len(diffs) > 0; got != tt.drifted {
+			t.Errorf("%s: drifted = %v (%v), want %v", tt.name, got, diffs, tt.drifted)
+		}
+	}
+
+	got := normalizeSpec("", map[string]any{"syncPolicy": map[string]any{"automated": map[string]any{"prune": false}}})
+	want := map[string]any{"syncPolicy": map[string]any{"automated": map[string]any{}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("normalizeSpec kept %v, want %v", got, want)
+	}
+}
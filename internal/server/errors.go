@@ -0,0 +1,181 @@
+package server
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// APIError is returned when ArgoCD responds with a non-success status code
+type APIError struct {
+	StatusCode int
+	// Code is the gRPC status code from ArgoCD's error body, if present
+	Code int
+	// Message is the human readable message from ArgoCD's error body
+	Message string
+	// Body is the raw response body
+	Body string
+}
+
+// Sentinel errors for the ArgoCD failures handlers commonly react to. An
+// APIError matches the one for its status code, so callers can test with
+// errors.Is(err, ErrNotFound) instead of inspecting the status themselves.
+var (
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+)
+
+// statusErrors maps the status codes with a sentinel error to it
+var statusErrors = map[int]error{
+	http.StatusUnauthorized: ErrUnauthorized,
+	http.StatusForbidden:    ErrForbidden,
+	http.StatusNotFound:     ErrNotFound,
+	http.StatusConflict:     ErrConflict,
+}
+
+// Is reports whether target is the sentinel error for e's status code
+func (e *APIError) Is(target error) bool {
+	sentinel, ok := statusErrors[e.StatusCode]
+	return ok && sentinel == target
+}
+
+func (e *APIError) Error() string {
+	detail := e.Message
+	if detail == "" {
+		detail = e.Body
+	}
+	if detail == "" {
+		detail = http.StatusText(e.StatusCode)
+	}
+	return fmt.Sprintf("ArgoCD API returned status %d: %s", e.StatusCode, detail)
+}
+
+// newAPIError builds an APIError from a failed ArgoCD response. ArgoCD
+// reports errors as {"error": ..., "code": ..., "message": ...}; the raw
+// body is kept for anything that doesn't parse that way.
+func newAPIError(resp *http.Response) error {
+	// ArgoCD or a proxy in front of it may echo the request's credentials
+	token := ""
+	if resp.Request != nil {
+		token = strings.TrimSpace(strings.TrimPrefix(resp.Request.Header.Get("Authorization"), "Bearer "))
+	}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+	body := redactToken(string(data), token)
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Body:       strings.TrimSpace(body),
+	}
+
+	var parsed struct {
+		Error   string `json:"error"`
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal([]byte(body), &parsed); err == nil {
+		apiErr.Code = parsed.Code
+		apiErr.Message = firstNonEmpty(parsed.Message, parsed.Error)
+	}
+
+	level := slog.LevelWarn
+	if resp.StatusCode >= http.StatusInternalServerError {
+		level = slog.LevelError
+	}
+	attrs := []any{"status", resp.StatusCode, "error", apiErr.Message}
+	if resp.Request != nil {
+		attrs = append(attrs, "method", resp.Request.Method, "path", resp.Request.URL.Path)
+	}
+	slog.Log(context.Background(), level, "ArgoCD API request failed", attrs...)
+	return apiErr
+}
+
+// ToolError is the structured payload returned to MCP clients when a tool fails
+type ToolError struct {
+	Error       string `json:"error"`
+	Remediation string `json:"remediation,omitempty"`
+}
+
+// toolError converts err into an MCP tool error result carrying a remediation hint
+func toolError(err error) (*mcp.CallToolResult, any, error) {
+	payload, marshalErr := json.MarshalIndent(ToolError{
+		Error:       err.Error(),
+		Remediation: remediationFor(err),
+	}, "", "  ")
+	if marshalErr != nil {
+		return nil, nil, err
+	}
+
+	return &mcp.CallToolResult{
+		IsError: true,
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: string(payload)},
+		},
+	}, nil, nil
+}
+
+// remediationFor maps known ArgoCD failures to a suggested next step.
+// It returns an empty string when there is nothing useful to suggest.
+func remediationFor(err error) string {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		body := strings.ToLower(apiErr.Body)
+
+		switch {
+		case strings.Contains(body, "sync window"):
+			return "The operation is blocked by the project's sync windows. Check the AppProject's syncWindows and retry once a window allows it, or use a manual sync if the window permits."
+		case strings.Contains(body, "repository not accessible"),
+			strings.Contains(body, "repository not found"),
+			strings.Contains(body, "authentication required"):
+			return "ArgoCD cannot access the source repository. Verify the repository is registered in ArgoCD with valid credentials and that the repoURL matches exactly."
+		case strings.Contains(body, "not permitted in project"),
+			strings.Contains(body, "is not permitted"):
+			return "The application's source or destination is not allowed by its AppProject. Check the project's sourceRepos and destinations."
+		}
+
+		switch {
+		case errors.Is(err, ErrUnauthorized):
+			return "Check that ARGOCD_AUTH_TOKEN (or the file named by ARGOCD_AUTH_TOKEN_FILE) is set, valid, and not expired. Generate a new one with: argocd account generate-token --account <account-name>"
+		case errors.Is(err, ErrForbidden):
+			return "The configured account lacks RBAC permission for this action. Review the ArgoCD RBAC policy (argocd-rbac-cm) for the account."
+		case errors.Is(err, ErrNotFound):
+			return "The requested object does not exist or is not visible to this account. Check the name and, for applications, the app namespace."
+		case errors.Is(err, ErrConflict):
+			return "The object already exists or was modified concurrently. Fetch the current state and retry."
+		case apiErr.StatusCode >= http.StatusInternalServerError:
+			return "ArgoCD reported a server-side error. Check the argocd-server and argocd-repo-server logs, then retry."
+		}
+		return ""
+	}
+
+	var certErr x509.UnknownAuthorityError
+	var hostErr x509.HostnameError
+	if errors.As(err, &certErr) || errors.As(err, &hostErr) {
+		return "TLS verification failed. Use a trusted certificate for ArgoCD, point ARGOCD_CA_CERT at the CA that signed it, or set ARGOCD_INSECURE=true for development setups."
+	}
+
+	var tooLarge *ResponseTooLargeError
+	if errors.As(err, &tooLarge) {
+		return "Narrow the request, e.g. filter by project, or raise ARGOCD_MAX_RESPONSE_BYTES if responses this large are expected."
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "The request hit its deadline before ArgoCD answered. Check ArgoCD's responsiveness, or raise MCP_REQUEST_TIMEOUT (whole MCP request) or ARGOCD_REQUEST_TIMEOUT (single ArgoCD call) for slow operations."
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return "ArgoCD could not be reached. Verify ARGOCD_SERVER and network connectivity to the ArgoCD API server."
+	}
+
+	return ""
+}
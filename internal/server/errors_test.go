@@ -0,0 +1,66 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAPIErrorIs(t *testing.T) {
+	sentinels := []error{ErrUnauthorized, ErrForbidden, ErrNotFound, ErrConflict}
+	tests := []struct {
+		status int
+		want   error
+	}{
+		{http.StatusUnauthorized, ErrUnauthorized},
+		{http.StatusForbidden, ErrForbidden},
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusConflict, ErrConflict},
+		{http.StatusInternalServerError, nil},
+	}
+	for _, tt := range tests {
+		// Handlers usually add context around the APIError
+		err := fmt.Errorf("application %q: %w", "guestbook", &APIError{StatusCode: tt.status})
+		for _, sentinel := range sentinels {
+			if got := errors.Is(err, sentinel); got != (sentinel == tt.want) {
+				t.Errorf("status %d: errors.Is(err, %v) = %v", tt.status, sentinel, got)
+			}
+		}
+	}
+}
+
+func TestRemediationFor(t *testing.T) {
+	// A test server's self-signed certificate fails verification the same
+	// way an untrusted ArgoCD CA does
+	srv := httptest.NewUnstartedServer(http.NotFoundHandler())
+	srv.Config.ErrorLog = log.New(io.Discard, "", 0)
+	srv.StartTLS()
+	defer srv.Close()
+	_, certErr := http.Get(srv.URL)
+	if certErr == nil {
+		t.Fatal("expected a certificate verification error")
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"unknown authority", fmt.Errorf("failed to list applications: %w", certErr), "TLS verification failed"},
+		{"unauthorized", &APIError{StatusCode: http.StatusUnauthorized}, "ARGOCD_AUTH_TOKEN"},
+		{"deadline", fmt.Errorf("request: %w", context.DeadlineExceeded), "deadline"},
+		{"unrelated", errors.New("boom"), ""},
+	}
+	for _, tt := range tests {
+		got := remediationFor(tt.err)
+		if tt.want == "" && got != "" || !strings.Contains(got, tt.want) {
+			t.Errorf("%s: remediationFor() = %q, want it to mention %q", tt.name, got, tt.want)
+		}
+	}
+}
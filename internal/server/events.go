@@ -0,0 +1,173 @@
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	defaultEventsLimit = 50
+	maxEventsLimit     = 500
+)
+
+// Event is a Kubernetes event related to an application or its resources
+type Event struct {
+	Metadata struct {
+		Name              string `json:"name"`
+		UID               string `json:"uid"`
+		CreationTimestamp string `json:"creationTimestamp,omitempty"`
+	} `json:"metadata"`
+	InvolvedObject struct {
+		Kind      string `json:"kind"`
+		Name      string `json:"name"`
+		Namespace string `json:"namespace,omitempty"`
+	} `json:"involvedObject"`
+	Type           string `json:"type,omitempty"`
+	Reason         string `json:"reason,omitempty"`
+	Message        string `json:"message,omitempty"`
+	Count          int    `json:"count,omitempty"`
+	FirstTimestamp string `json:"firstTimestamp,omitempty"`
+	LastTimestamp  string `json:"lastTimestamp,omitempty"`
+	EventTime      string `json:"eventTime,omitempty"`
+	Source         struct {
+		Component string `json:"component,omitempty"`
+	} `json:"source,omitempty"`
+}
+
+// EventList is the events response of the ArgoCD API
+type EventList struct {
+	Metadata struct {
+		Continue string `json:"continue,omitempty"`
+	} `json:"metadata"`
+	Items []Event `json:"items"`
+}
+
+// ApplicationEventsArgs are the arguments for the get_application_events tool
+type ApplicationEventsArgs struct {
+	Name              string `json:"name" jsonschema:"the application name"`
+	ResourceNamespace string `json:"resource_namespace,omitempty" jsonschema:"only events for a resource in this namespace (requires resource_name)"`
+	ResourceName      string `json:"resource_name,omitempty" jsonschema:"only events for the managed resource with this name instead of the application itself"`
+	ResourceUID       string `json:"resource_uid,omitempty" jsonschema:"only events for the managed resource with this UID"`
+	Limit             int    `json:"limit,omitempty" jsonschema:"maximum number of events to return (default 50, max 500)"`
+	Continue          string `json:"continue,omitempty" jsonschema:"the continue token from a previous page, to fetch older events"`
+}
+
+// ApplicationEventsPage is the result of the get_application_events tool
+type ApplicationEventsPage struct {
+	Application string  `json:"application"`
+	Events      []Event `json:"events"`
+	Continue    string  `json:"continue,omitempty"`
+	Remaining   int     `json:"remaining"`
+}
+
+// eventTime returns the most recent timestamp recorded on an event
+func eventTime(e *Event) string {
+	return firstNonEmpty(e.LastTimestamp, e.EventTime, e.FirstTimestamp, e.Metadata.CreationTimestamp)
+}
+
+// eventCursor is the position of the last event on a page. Positions are
+// by content rather than offset so new events don't shift older pages.
+type eventCursor struct {
+	time string
+	uid  string
+}
+
+func (c eventCursor) encode() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(c.time + "|" + c.uid))
+}
+
+func decodeEventCursor(token string) (eventCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return eventCursor{}, fmt.Errorf("invalid continue token")
+	}
+	t, uid, ok := strings.Cut(string(data), "|")
+	if !ok {
+		return eventCursor{}, fmt.Errorf("invalid continue token")
+	}
+	return eventCursor{time: t, uid: uid}, nil
+}
+
+// newerThan orders events newest first, breaking ties by UID
+func (c eventCursor) newerThan(other eventCursor) bool {
+	if c.time != other.time {
+		return c.time > other.time
+	}
+	return c.uid > other.uid
+}
+
+func (s *MCPServer) handleGetApplicationEvents(ctx context.Context, req *mcp.CallToolRequest, args ApplicationEventsArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	if args.Name == "" {
+		return toolError(fmt.Errorf("name is required"))
+	}
+	limit := args.Limit
+	if limit <= 0 {
+		limit = defaultEventsLimit
+	}
+	limit = min(limit, maxEventsLimit)
+
+	var after *eventCursor
+	if args.Continue != "" {
+		cursor, err := decodeEventCursor(args.Continue)
+		if err != nil {
+			return toolError(err)
+		}
+		after = &cursor
+	}
+
+	query := url.Values{}
+	if args.ResourceNamespace != "" {
+		query.Set("resourceNamespace", args.ResourceNamespace)
+	}
+	if args.ResourceName != "" {
+		query.Set("resourceName", args.ResourceName)
+	}
+	if args.ResourceUID != "" {
+		query.Set("resourceUID", args.ResourceUID)
+	}
+
+	// ArgoCD returns the full event list and doesn't accept limit/continue,
+	// so pages are cut here
+	var events EventList
+	if err := s.argocdGet(ctx, applicationPath(args.Name)+"/events", query, &events); err != nil {
+		return toolError(err)
+	}
+
+	cursors := make(map[*Event]eventCursor, len(events.Items))
+	items := make([]*Event, 0, len(events.Items))
+	for i := range events.Items {
+		e := &events.Items[i]
+		cursor := eventCursor{time: eventTime(e), uid: firstNonEmpty(e.Metadata.UID, e.Metadata.Name)}
+		if after != nil && !after.newerThan(cursor) {
+			continue
+		}
+		cursors[e] = cursor
+		items = append(items, e)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return cursors[items[i]].newerThan(cursors[items[j]])
+	})
+
+	page := &ApplicationEventsPage{
+		Application: args.Name,
+		Events:      []Event{},
+	}
+	for i, e := range items {
+		if i == limit {
+			page.Continue = cursors[items[i-1]].encode()
+			page.Remaining = len(items) - limit
+			break
+		}
+		page.Events = append(page.Events, *e)
+	}
+
+	return toolJSON(page)
+}
@@ -0,0 +1,148 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"sigs.k8s.io/yaml"
+)
+
+// clusterSecretLabel marks a Secret as a declarative ArgoCD cluster
+const clusterSecretLabel = "argocd.argoproj.io/secret-type"
+
+// invalidNameChars matches characters not allowed in a Kubernetes object name
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9.-]+`)
+
+// ExportClustersArgs are the arguments for the export_clusters tool
+type ExportClustersArgs struct {
+	Namespace string `json:"namespace,omitempty" jsonschema:"namespace ArgoCD is installed in, used for the exported Secrets (default argocd)"`
+}
+
+// clusterSecretName derives a valid Secret name from a cluster name
+func clusterSecretName(name string) string {
+	name = strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(name), "-"), "-.")
+	if name == "" {
+		name = "unnamed"
+	}
+	return "cluster-" + name
+}
+
+// clusterSecret converts a raw cluster from the API into a declarative
+// cluster Secret with credentials redacted, returning the paths it redacted
+func clusterSecret(cluster map[string]any, namespace string) (map[string]any, []string, error) {
+	name, _ := cluster["name"].(string)
+	server, _ := cluster["server"].(string)
+
+	config, _ := cluster["config"].(map[string]any)
+	if config == nil {
+		config = map[string]any{}
+	}
+	redacted := redactSecretFields(config, "config")
+	// Keep placeholders readable rather than \u003c-escaped
+	var configJSON bytes.Buffer
+	enc := json.NewEncoder(&configJSON)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(config); err != nil {
+		return nil, nil, fmt.Errorf("failed to encode config of cluster %q: %w", name, err)
+	}
+
+	stringData := map[string]any{
+		"name":   name,
+		"server": server,
+		"config": strings.TrimSpace(configJSON.String()),
+	}
+	if project, _ := cluster["project"].(string); project != "" {
+		stringData["project"] = project
+	}
+	if namespaces, _ := cluster["namespaces"].([]any); len(namespaces) > 0 {
+		names := make([]string, 0, len(namespaces))
+		for _, ns := range namespaces {
+			if s, ok := ns.(string); ok {
+				names = append(names, s)
+			}
+		}
+		stringData["namespaces"] = strings.Join(names, ",")
+	}
+	if clusterResources, _ := cluster["clusterResources"].(bool); clusterResources {
+		stringData["clusterResources"] = "true"
+	}
+
+	labels := map[string]any{clusterSecretLabel: "cluster"}
+	if extra, ok := cluster["labels"].(map[string]any); ok {
+		for k, v := range extra {
+			labels[k] = v
+		}
+	}
+	metadata := map[string]any{
+		"name":      clusterSecretName(name),
+		"namespace": namespace,
+		"labels":    labels,
+	}
+	if annotations, ok := cluster["annotations"].(map[string]any); ok && len(annotations) > 0 {
+		metadata["annotations"] = annotations
+	}
+
+	return map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"type":       "Opaque",
+		"metadata":   metadata,
+		"stringData": stringData,
+	}, redacted, nil
+}
+
+func (s *MCPServer) handleExportClusters(ctx context.Context, req *mcp.CallToolRequest, args ExportClustersArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	namespace := args.Namespace
+	if namespace == "" {
+		namespace = "argocd"
+	}
+
+	// Decode loosely so fields this server doesn't model survive the export
+	var clusters struct {
+		Items []map[string]any `json:"items"`
+	}
+	if err := s.argocdGet(ctx, "/api/v1/clusters", nil, &clusters); err != nil {
+		return toolError(err)
+	}
+
+	sort.Slice(clusters.Items, func(i, j int) bool {
+		a, _ := clusters.Items[i]["server"].(string)
+		b, _ := clusters.Items[j]["server"].(string)
+		return a < b
+	})
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %d clusters exported from %s as declarative cluster Secrets.\n", len(clusters.Items), s.serverURL(ctx))
+	sb.WriteString("# Credentials were replaced with <REDACTED:...> placeholders; fill them in before applying.\n")
+
+	for _, cluster := range clusters.Items {
+		secret, redacted, err := clusterSecret(cluster, namespace)
+		if err != nil {
+			return toolError(err)
+		}
+		data, err := yaml.Marshal(secret)
+		if err != nil {
+			return toolError(fmt.Errorf("failed to encode cluster secret: %w", err))
+		}
+
+		sb.WriteString("---\n")
+		if len(redacted) > 0 {
+			fmt.Fprintf(&sb, "# redacted: %s\n", strings.Join(redacted, ", "))
+		}
+		sb.Write(data)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: sb.String()},
+		},
+	}, nil, nil
+}
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// compactJSONEnv switches JSON results from indented to compact output,
+	// which saves tokens for LLM clients on large results
+	compactJSONEnv = "MCP_COMPACT_JSON"
+
+	// compactArg is the optional tool argument overriding MCP_COMPACT_JSON
+	// for a single call
+	compactArg = "compact"
+)
+
+// compactText returns text without insignificant whitespace if it is JSON,
+// and unchanged otherwise
+func compactText(text string) string {
+	if text == "" || (text[0] != '{' && text[0] != '[') {
+		return text
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, []byte(text)); err != nil {
+		return text
+	}
+	return buf.String()
+}
+
+// compactRequested reports whether a request's JSON output should be
+// compact: the tool's compact argument when given, MCP_COMPACT_JSON otherwise
+func (s *MCPServer) compactRequested(req mcp.Request) bool {
+	call, ok := req.(*mcp.CallToolRequest)
+	if !ok || call.Params == nil || len(call.Params.Arguments) == 0 {
+		return s.compactJSON
+	}
+	var args struct {
+		Compact *bool `json:"compact"`
+	}
+	if err := json.Unmarshal(call.Params.Arguments, &args); err != nil || args.Compact == nil {
+		return s.compactJSON
+	}
+	return *args.Compact
+}
+
+// formatMiddleware compacts the JSON that tools and resources return when
+// requested. Handlers always produce indented JSON, so compacting it here
+// keeps them unaware of the setting.
+func (s *MCPServer) formatMiddleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		result, err := next(ctx, method, req)
+		if err != nil || !s.compactRequested(req) {
+			return result, err
+		}
+
+		switch r := result.(type) {
+		case *mcp.CallToolResult:
+			for _, content := range r.Content {
+				if text, ok := content.(*mcp.TextContent); ok {
+					text.Text = compactText(text.Text)
+				}
+			}
+		case *mcp.ReadResourceResult:
+			for _, contents := range r.Contents {
+				if contents.MIMEType == "application/json" {
+					contents.Text = compactText(contents.Text)
+				}
+			}
+		}
+		return result, nil
+	}
+}
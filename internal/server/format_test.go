@@ -0,0 +1,50 @@
+package server
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestCompactText(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"{\n  \"name\": \"guestbook\",\n  \"labels\": [\n    \"a b\"\n  ]\n}", `{"name":"guestbook","labels":["a b"]}`},
+		{"[\n  1,\n  2\n]", "[1,2]"},
+		{"Application \"guestbook\" deleted", "Application \"guestbook\" deleted"},
+		{"{not json", "{not json"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := compactText(tt.text); got != tt.want {
+			t.Errorf("compactText(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestCompactRequested(t *testing.T) {
+	call := func(args string) mcp.Request {
+		return &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: "list_applications", Arguments: json.RawMessage(args)}}
+	}
+	tests := []struct {
+		name     string
+		envValue bool
+		req      mcp.Request
+		want     bool
+	}{
+		{"default pretty", false, call(`{}`), false},
+		{"env default", true, call(`{"project":"default"}`), true},
+		{"argument enables", false, call(`{"compact":true}`), true},
+		{"argument overrides env", true, call(`{"compact":false}`), false},
+		{"resource read uses env", true, &mcp.ReadResourceRequest{}, true},
+	}
+	for _, tt := range tests {
+		s := &MCPServer{compactJSON: tt.envValue}
+		if got := s.compactRequested(tt.req); got != tt.want {
+			t.Errorf("%s: compactRequested = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package server
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// deployDedupeWindow is how close a locally recorded sync must be to a
+// history entry to be treated as the same deploy
+const deployDedupeWindow = 2 * time.Minute
+
+// RevisionHistory is a single entry of an application's deployment history
+type RevisionHistory struct {
+	ID              int64  `json:"id"`
+	Revision        string `json:"revision"`
+	DeployedAt      string `json:"deployedAt"`
+	DeployStartedAt string `json:"deployStartedAt,omitempty"`
+}
+
+// DeployFrequencyArgs are the arguments for the get_deploy_frequency tool
+type DeployFrequencyArgs struct {
+	WindowDays int `json:"window_days,omitempty" jsonschema:"how many days back to count deploys (default 7)"`
+	Limit      int `json:"limit,omitempty" jsonschema:"maximum number of applications to return (default 20)"`
+}
+
+// DeployFrequency is one leaderboard entry
+type DeployFrequency struct {
+	Name            string `json:"name"`
+	Deploys         int    `json:"deploys"`
+	FromHistory     int    `json:"from_history"`
+	ObservedLocally int    `json:"observed_locally"`
+	LastDeploy      string `json:"last_deploy,omitempty"`
+}
+
+// DeployFrequencyReport is the result of the get_deploy_frequency tool
+type DeployFrequencyReport struct {
+	WindowDays  int               `json:"window_days"`
+	Note        string            `json:"note"`
+	Leaderboard []DeployFrequency `json:"leaderboard"`
+}
+
+func (s *MCPServer) handleGetDeployFrequency(ctx context.Context, req *mcp.CallToolRequest, args DeployFrequencyArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	windowDays := args.WindowDays
+	if windowDays <= 0 {
+		windowDays = 7
+	}
+	limit := args.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	since := time.Now().Add(-time.Duration(windowDays) * 24 * time.Hour)
+
+	apps, err := s.getArgocdApplications(ctx, ApplicationFilter{})
+	if err != nil {
+		return toolError(err)
+	}
+
+	// Deploy times per app from ArgoCD's own history
+	deploys := map[string][]time.Time{}
+	for _, app := range apps.Items {
+		for _, entry := range app.Status.History {
+			at, err := time.Parse(time.RFC3339, firstNonEmpty(entry.DeployStartedAt, entry.DeployedAt))
+			if err != nil || at.Before(since) {
+				continue
+			}
+			deploys[app.Metadata.Name] = append(deploys[app.Metadata.Name], at)
+		}
+	}
+
+	entries := map[string]*DeployFrequency{}
+	entry := func(name string) *DeployFrequency {
+		if e, ok := entries[name]; ok {
+			return e
+		}
+		e := &DeployFrequency{Name: name}
+		entries[name] = e
+		return e
+	}
+	last := map[string]time.Time{}
+	note := func(name string, at time.Time) {
+		if at.After(last[name]) {
+			last[name] = at
+		}
+	}
+
+	for name, times := range deploys {
+		e := entry(name)
+		e.FromHistory = len(times)
+		for _, at := range times {
+			note(name, at)
+		}
+	}
+
+	// Add syncs triggered through this server that history doesn't already cover
+	for _, op := range s.history.operationsSince(s.instance(ctx).name, since) {
+		duplicate := false
+		for _, at := range deploys[op.App] {
+			if d := op.At.Sub(at); d < deployDedupeWindow && d > -deployDedupeWindow {
+				duplicate = true
+				break
+			}
+		}
+		if duplicate {
+			continue
+		}
+		entry(op.App).ObservedLocally++
+		note(op.App, op.At)
+	}
+
+	report := &DeployFrequencyReport{
+		WindowDays:  windowDays,
+		Note:        "ArgoCD keeps only the most recent deployments per application (revisionHistoryLimit, 10 by default), so frequently deployed apps are undercounted over long windows. Syncs triggered through this server are added when history doesn't already include them, and only since the server started.",
+		Leaderboard: []DeployFrequency{},
+	}
+	for name, e := range entries {
+		e.Deploys = e.FromHistory + e.ObservedLocally
+		e.LastDeploy = last[name].UTC().Format(time.RFC3339)
+		report.Leaderboard = append(report.Leaderboard, *e)
+	}
+
+	sort.Slice(report.Leaderboard, func(i, j int) bool {
+		a, b := report.Leaderboard[i], report.Leaderboard[j]
+		if a.Deploys != b.Deploys {
+			return a.Deploys > b.Deploys
+		}
+		return a.Name < b.Name
+	})
+	if len(report.Leaderboard) > limit {
+		report.Leaderboard = report.Leaderboard[:limit]
+	}
+
+	return toolJSON(report)
+}
@@ -0,0 +1,85 @@
+package server
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
+
+var (
+	globCacheMu sync.Mutex
+	globCache   = map[string]*regexp.Regexp{}
+)
+
+// globMatch reports whether text matches pattern using the same rules ArgoCD
+// applies to project sourceRepos and destinations: patterns have no path
+// separators, so * matches any run of characters including '/', ? matches one
+// character, [abc] matches a character class and {a,b} matches alternatives.
+func globMatch(pattern, text string) bool {
+	globCacheMu.Lock()
+	re, ok := globCache[pattern]
+	if !ok {
+		var err error
+		re, err = regexp.Compile(globToRegexp(pattern))
+		if err != nil {
+			// An invalid pattern only matches itself literally
+			re = regexp.MustCompile("^" + regexp.QuoteMeta(pattern) + "$")
+		}
+		globCache[pattern] = re
+	}
+	globCacheMu.Unlock()
+
+	return re.MatchString(text)
+}
+
+// globToRegexp translates a glob pattern into an anchored regular expression
+func globToRegexp(pattern string) string {
+	var sb strings.Builder
+	sb.WriteString("^")
+
+	inClass, braceDepth := false, 0
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch {
+		case inClass:
+			if c == ']' {
+				inClass = false
+			}
+			if c == '\\' {
+				sb.WriteByte('\\')
+			}
+			sb.WriteByte(c)
+		case c == '\\' && i+1 < len(pattern):
+			i++
+			sb.WriteString(regexp.QuoteMeta(string(pattern[i])))
+		case c == '*':
+			sb.WriteString(".*")
+			// ** behaves like * when there are no separators
+			for i+1 < len(pattern) && pattern[i+1] == '*' {
+				i++
+			}
+		case c == '?':
+			sb.WriteString(".")
+		case c == '[':
+			inClass = true
+			sb.WriteByte('[')
+			if i+1 < len(pattern) && pattern[i+1] == '!' {
+				sb.WriteByte('^')
+				i++
+			}
+		case c == '{':
+			braceDepth++
+			sb.WriteString("(?:")
+		case c == '}' && braceDepth > 0:
+			braceDepth--
+			sb.WriteString(")")
+		case c == ',' && braceDepth > 0:
+			sb.WriteString("|")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	sb.WriteString("$")
+	return sb.String()
+}
@@ -0,0 +1,103 @@
+package server
+
+import "testing"
+
+func TestGlobMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		text    string
+		want    bool
+	}{
+		{"*", "https://github.com/org/repo", true},
+		{"https://github.com/org/*", "https://github.com/org/repo", true},
+		{"https://github.com/org/*", "https://github.com/org/team/repo", true},
+		{"https://github.com/org/*", "https://github.com/other/repo", false},
+		{"https://github.com/org/repo", "https://github.com/org/repo", true},
+		{"https://github.com/org/repo", "https://github.com/org/repo2", false},
+		{"team-?", "team-a", true},
+		{"team-?", "team-ab", false},
+		{"team-[ab]", "team-b", true},
+		{"team-[!ab]", "team-b", false},
+		{"team-[!ab]", "team-c", true},
+		{"{dev,staging}-*", "staging-web", true},
+		{"{dev,staging}-*", "prod-web", false},
+		{"app.example.com", "appXexample.com", false},
+		{"**", "a/b/c", true},
+	}
+
+	for _, tt := range tests {
+		if got := globMatch(tt.pattern, tt.text); got != tt.want {
+			t.Errorf("globMatch(%q, %q) = %v, want %v", tt.pattern, tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestSourcePermitted(t *testing.T) {
+	repos := []string{"https://github.com/org/*", "!https://github.com/org/secret"}
+
+	tests := []struct {
+		repo string
+		want bool
+	}{
+		{"https://github.com/org/app", true},
+		{"https://github.com/Org/App.git", true},
+		{"https://github.com/org/secret", false},
+		// As in ArgoCD, a deny pattern counts as a match for any other repository
+		{"https://gitlab.com/org/app", true},
+	}
+
+	for _, tt := range tests {
+		if got := sourcePermitted(repos, tt.repo); got != tt.want {
+			t.Errorf("sourcePermitted(%q) = %v, want %v", tt.repo, got, tt.want)
+		}
+	}
+
+	if sourcePermitted([]string{"https://github.com/org/*"}, "https://gitlab.com/org/app") {
+		t.Error("sourcePermitted allowed a repository matching no pattern")
+	}
+}
+
+func TestDeniedSources(t *testing.T) {
+	repos := []string{"https://github.com/org/*"}
+
+	var app ArgocdApplication
+	app.Spec.Source.RepoURL = "https://gitlab.com/org/ignored"
+	app.Spec.Sources = []ApplicationSource{
+		{RepoURL: "https://github.com/org/app"},
+		{RepoURL: "https://charts.example.com"},
+	}
+	if got := deniedSources(repos, &app); len(got) != 1 || got[0] != "https://charts.example.com" {
+		t.Errorf("deniedSources(multi-source) = %v, want only the chart repository", got)
+	}
+
+	app.Spec.Sources = nil
+	if got := deniedSources(repos, &app); len(got) != 1 || got[0] != "https://gitlab.com/org/ignored" {
+		t.Errorf("deniedSources(single source) = %v, want spec.source", got)
+	}
+}
+
+func TestDestinationPermitted(t *testing.T) {
+	dests := []ProjectDestination{
+		{Server: "https://kubernetes.default.svc", Namespace: "team-*"},
+		{Name: "prod-*", Namespace: "*"},
+		{Server: "*", Namespace: "!kube-system"},
+	}
+
+	tests := []struct {
+		server, name, namespace string
+		want                    bool
+	}{
+		{"https://kubernetes.default.svc", "", "team-a", true},
+		{"https://kubernetes.default.svc", "", "other", true},
+		{"https://kubernetes.default.svc", "", "kube-system", false},
+		{"", "prod-eu", "anything", true},
+		{"https://dev.example.com", "dev-eu", "default", true},
+		{"", "dev-eu", "default", false},
+	}
+
+	for _, tt := range tests {
+		if got := destinationPermitted(dests, tt.server, tt.name, tt.namespace); got != tt.want {
+			t.Errorf("destinationPermitted(%q, %q, %q) = %v, want %v", tt.server, tt.name, tt.namespace, got, tt.want)
+		}
+	}
+}
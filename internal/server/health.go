@@ -0,0 +1,71 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// readinessCacheTTL is how long a readiness result is reused, so frequent
+	// probes don't turn into a stream of ArgoCD requests
+	readinessCacheTTL = 5 * time.Second
+
+	// readinessTimeout bounds the ArgoCD check behind /readyz
+	readinessTimeout = 5 * time.Second
+)
+
+// readinessCheck caches the outcome of checking that ArgoCD is reachable
+type readinessCheck struct {
+	check func(context.Context) error
+	ttl   time.Duration
+
+	mu        sync.Mutex
+	checkedAt time.Time
+	err       error
+}
+
+// result returns the cached readiness result, re-checking once it expired.
+// Concurrent probes wait for a single check instead of each running one.
+func (r *readinessCheck) result(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.checkedAt.IsZero() && time.Since(r.checkedAt) < r.ttl {
+		return r.err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readinessTimeout)
+	defer cancel()
+	r.err = r.check(ctx)
+	r.checkedAt = time.Now()
+	return r.err
+}
+
+// checkArgocdReachable asks the primary ArgoCD instance for its version, a
+// cheap call that needs no credentials. In core mode it lists a single
+// Application from Kubernetes instead.
+func (s *MCPServer) checkArgocdReachable(ctx context.Context) error {
+	if s.core != nil {
+		return s.core.ping(ctx, s.maxResponseBytes)
+	}
+	return s.argocdGet(ctx, "/api/version", nil, nil)
+}
+
+// handleHealthz reports liveness: the process is up and serving HTTP
+func handleHealthz(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Write([]byte("ok\n"))
+}
+
+// handleReadyz reports readiness: ArgoCD answered recently
+func (r *readinessCheck) handleReadyz(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if err := r.result(req.Context()); err != nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte("ArgoCD is not reachable: " + err.Error() + "\n"))
+		return
+	}
+	w.Write([]byte("ok\n"))
+}
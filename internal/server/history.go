@@ -0,0 +1,197 @@
+package server
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// maxSnapshotsPerApp bounds the status history kept for each application
+const maxSnapshotsPerApp = 100
+
+// statusSnapshot is the status of an application observed at a point in time
+type statusSnapshot struct {
+	At     time.Time
+	Health string
+	Sync   string
+}
+
+// maxRecordedOperations bounds the number of locally triggered syncs kept
+const maxRecordedOperations = 1000
+
+// recordedOperation is a sync triggered through this server
+type recordedOperation struct {
+	Instance string
+	App      string
+	At       time.Time
+}
+
+// historyKey identifies an application on one ArgoCD instance, since
+// instances can have applications of the same name
+type historyKey struct {
+	Instance string
+	App      string
+}
+
+// statusHistory records application statuses observed by this server and the
+// syncs it triggered. It only knows what the server has seen, so it starts
+// empty on every restart.
+type statusHistory struct {
+	mu         sync.Mutex
+	started    time.Time
+	snapshots  map[historyKey][]statusSnapshot
+	operations []recordedOperation
+}
+
+func newStatusHistory() *statusHistory {
+	return &statusHistory{
+		started:   time.Now(),
+		snapshots: make(map[historyKey][]statusSnapshot),
+	}
+}
+
+// record stores the current status of each app of an instance, skipping apps
+// whose status is unchanged since the last snapshot
+func (h *statusHistory) record(instance string, apps []ArgocdApplication) {
+	now := time.Now()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, app := range apps {
+		key := historyKey{Instance: instance, App: app.Metadata.Name}
+		snapshot := statusSnapshot{
+			At:     now,
+			Health: app.Status.Health.Status,
+			Sync:   app.Status.Sync.Status,
+		}
+
+		snapshots := h.snapshots[key]
+		if n := len(snapshots); n > 0 && snapshots[n-1].Health == snapshot.Health && snapshots[n-1].Sync == snapshot.Sync {
+			continue
+		}
+		snapshots = append(snapshots, snapshot)
+		if len(snapshots) > maxSnapshotsPerApp {
+			snapshots = snapshots[len(snapshots)-maxSnapshotsPerApp:]
+		}
+		h.snapshots[key] = snapshots
+	}
+}
+
+// recordOperation remembers that a sync of app on an instance was triggered now
+func (h *statusHistory) recordOperation(instance, app string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.operations = append(h.operations, recordedOperation{Instance: instance, App: app, At: time.Now()})
+	if len(h.operations) > maxRecordedOperations {
+		h.operations = h.operations[len(h.operations)-maxRecordedOperations:]
+	}
+}
+
+// operationsSince returns the syncs triggered on an instance since the given
+// time
+func (h *statusHistory) operationsSince(instance string, since time.Time) []recordedOperation {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var ops []recordedOperation
+	for _, op := range h.operations {
+		if op.Instance == instance && !op.At.Before(since) {
+			ops = append(ops, op)
+		}
+	}
+	return ops
+}
+
+// HealthTransition is an observed change in an application's health
+type HealthTransition struct {
+	Name       string `json:"name"`
+	From       string `json:"from"`
+	To         string `json:"to"`
+	ObservedAt string `json:"observed_at"`
+}
+
+// healthTransitions returns the health changes observed on an instance since
+// the given time
+func (h *statusHistory) healthTransitions(instance string, since time.Time) []HealthTransition {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	transitions := []HealthTransition{}
+	for key, snapshots := range h.snapshots {
+		if key.Instance != instance {
+			continue
+		}
+		for i := 1; i < len(snapshots); i++ {
+			prev, cur := snapshots[i-1], snapshots[i]
+			if cur.At.Before(since) || prev.Health == cur.Health {
+				continue
+			}
+			transitions = append(transitions, HealthTransition{
+				Name:       key.App,
+				From:       prev.Health,
+				To:         cur.Health,
+				ObservedAt: cur.At.UTC().Format(time.RFC3339),
+			})
+		}
+	}
+
+	sort.Slice(transitions, func(i, j int) bool {
+		return transitions[i].ObservedAt > transitions[j].ObservedAt
+	})
+	return transitions
+}
+
+// HealthTransitionsArgs are the arguments for the list_health_transitions tool
+type HealthTransitionsArgs struct {
+	WindowMinutes int    `json:"window_minutes,omitempty" jsonschema:"how far back to look, in minutes (default 60)"`
+	FromStatus    string `json:"from_status,omitempty" jsonschema:"only include transitions from this health status, e.g. Healthy"`
+	ToStatus      string `json:"to_status,omitempty" jsonschema:"only include transitions to this health status, e.g. Degraded"`
+}
+
+// HealthTransitionsReport is the result of the list_health_transitions tool
+type HealthTransitionsReport struct {
+	WindowMinutes  int                `json:"window_minutes"`
+	ObservingSince string             `json:"observing_since"`
+	Note           string             `json:"note"`
+	Transitions    []HealthTransition `json:"transitions"`
+}
+
+func (s *MCPServer) handleListHealthTransitions(ctx context.Context, req *mcp.CallToolRequest, args HealthTransitionsArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	window := args.WindowMinutes
+	if window <= 0 {
+		window = 60
+	}
+
+	// Poll now so the current status is part of the record
+	if _, err := s.getArgocdApplications(ctx, ApplicationFilter{}); err != nil {
+		return toolError(err)
+	}
+
+	report := &HealthTransitionsReport{
+		WindowMinutes:  window,
+		ObservingSince: s.history.started.UTC().Format(time.RFC3339),
+		Note:           "Transitions are built from statuses this MCP server has observed each time it fetched applications, so changes that happened and reverted between polls, or before the server started, are not visible.",
+		Transitions:    []HealthTransition{},
+	}
+
+	since := time.Now().Add(-time.Duration(window) * time.Minute)
+	for _, t := range s.history.healthTransitions(s.instance(ctx).name, since) {
+		if args.FromStatus != "" && !strings.EqualFold(t.From, args.FromStatus) {
+			continue
+		}
+		if args.ToStatus != "" && !strings.EqualFold(t.To, args.ToStatus) {
+			continue
+		}
+		report.Transitions = append(report.Transitions, t)
+	}
+
+	return toolJSON(report)
+}
@@ -0,0 +1,41 @@
+package server
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStatusHistoryPerInstance(t *testing.T) {
+	app := func(health string) []ArgocdApplication {
+		var a ArgocdApplication
+		a.Metadata.Name = "web"
+		a.Status.Health.Status = health
+		return []ArgocdApplication{a}
+	}
+
+	h := newStatusHistory()
+	since := time.Now().Add(-time.Minute)
+	h.record("staging", app("Healthy"))
+	h.record("prod", app("Degraded"))
+	h.record("staging", app("Healthy"))
+	h.record("prod", app("Degraded"))
+
+	// Interleaved, the two apps would look like four transitions
+	if got := h.healthTransitions("staging", since); len(got) != 0 {
+		t.Errorf("staging transitions = %v, want none", got)
+	}
+
+	h.record("prod", app("Healthy"))
+	got := h.healthTransitions("prod", since)
+	if len(got) != 1 || got[0].From != "Degraded" || got[0].To != "Healthy" {
+		t.Errorf("prod transitions = %v, want Degraded -> Healthy", got)
+	}
+
+	h.recordOperation("prod", "web")
+	if ops := h.operationsSince("staging", since); len(ops) != 0 {
+		t.Errorf("staging operations = %v, want none", ops)
+	}
+	if ops := h.operationsSince("prod", since); len(ops) != 1 {
+		t.Errorf("prod operations = %v, want 1", ops)
+	}
+}
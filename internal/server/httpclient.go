@@ -0,0 +1,93 @@
+package server
+
+import (
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+// defaultHTTPTimeout bounds each ArgoCD HTTP call when ARGOCD_HTTP_TIMEOUT is unset
+const defaultHTTPTimeout = 30 * time.Second
+
+// httpClientConfig holds the HTTP client settings shared by every ArgoCD instance
+type httpClientConfig struct {
+	timeout time.Duration
+
+	// Connection pool tuning, e.g. for batch operations against one server.
+	// Zero values keep Go's defaults: no overall idle limit, 2 idle
+	// connections per host and no idle timeout.
+	maxIdleConns        int
+	maxIdleConnsPerHost int
+	idleConnTimeout     time.Duration
+
+	// proxyURL overrides the HTTPS_PROXY/HTTP_PROXY/NO_PROXY environment
+	// variables when set
+	proxyURL *url.URL
+}
+
+// loadHTTPClientConfig reads the ArgoCD HTTP client settings from the environment
+func loadHTTPClientConfig() (httpClientConfig, error) {
+	cfg := httpClientConfig{
+		timeout:             getEnvDuration("ARGOCD_HTTP_TIMEOUT", defaultHTTPTimeout),
+		maxIdleConns:        getEnvInt("ARGOCD_MAX_IDLE_CONNS", 0),
+		maxIdleConnsPerHost: getEnvInt("ARGOCD_MAX_IDLE_CONNS_PER_HOST", 0),
+		idleConnTimeout:     getEnvDuration("ARGOCD_IDLE_CONN_TIMEOUT", 0),
+	}
+	if cfg.timeout <= 0 {
+		slog.Warn("ARGOCD_HTTP_TIMEOUT must be positive, using default", "default", defaultHTTPTimeout)
+		cfg.timeout = defaultHTTPTimeout
+	}
+	if cfg.idleConnTimeout < 0 {
+		slog.Warn("ARGOCD_IDLE_CONN_TIMEOUT must not be negative, using no timeout")
+		cfg.idleConnTimeout = 0
+	}
+	slog.Info("ArgoCD HTTP timeout", "timeout", cfg.timeout)
+
+	if raw := os.Getenv("ARGOCD_PROXY_URL"); raw != "" {
+		proxyURL, err := parseProxyURL(raw)
+		if err != nil {
+			return cfg, err
+		}
+		cfg.proxyURL = proxyURL
+		slog.Info("Connecting to ArgoCD through proxy", "proxy", proxyURL.Redacted())
+	}
+	return cfg, nil
+}
+
+// parseProxyURL validates ARGOCD_PROXY_URL, which may point at an HTTP(S)
+// or SOCKS5 proxy
+func parseProxyURL(raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ARGOCD_PROXY_URL: %w", err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("invalid ARGOCD_PROXY_URL %q: scheme must be http, https, socks5 or socks5h", u.Redacted())
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid ARGOCD_PROXY_URL %q: missing host", u.Redacted())
+	}
+	return u, nil
+}
+
+// newTransport creates the transport for one ArgoCD instance. Unless
+// ARGOCD_PROXY_URL is set, it honors the standard proxy environment variables.
+func (c httpClientConfig) newTransport(tlsCfg *tls.Config) *http.Transport {
+	proxy := http.ProxyFromEnvironment
+	if c.proxyURL != nil {
+		proxy = http.ProxyURL(c.proxyURL)
+	}
+	return &http.Transport{
+		Proxy:               proxy,
+		TLSClientConfig:     tlsCfg,
+		MaxIdleConns:        c.maxIdleConns,
+		MaxIdleConnsPerHost: c.maxIdleConnsPerHost,
+		IdleConnTimeout:     c.idleConnTimeout,
+	}
+}
@@ -0,0 +1,268 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// instanceArg is the optional tool argument selecting an ArgoCD instance.
+	// It isn't called "server" because several tools already use that for a
+	// cluster's API server URL.
+	instanceArg = "argocd_server"
+
+	// defaultInstanceName names the single instance configured through
+	// ARGOCD_SERVER when ARGOCD_SERVERS is unset
+	defaultInstanceName = "default"
+)
+
+// argocdInstance is one ArgoCD server this MCP server can talk to
+type argocdInstance struct {
+	name       string
+	cfg        *ArgocdConfig
+	authMu     sync.RWMutex // guards cfg.AuthToken, which login replaces
+	httpClient *http.Client
+
+	// streamClient shares httpClient's transport without its overall
+	// timeout, for long-lived streams bounded by their context instead
+	streamClient *http.Client
+
+	versionMu sync.Mutex
+	version   *VersionInfo // cached by argocdVersion
+	versionAt time.Time
+}
+
+// instanceSpec is one entry of ARGOCD_SERVERS. ArgocdConfig never
+// (un)marshals the password, so it is read separately here.
+type instanceSpec struct {
+	ArgocdConfig
+	Password string `json:"password,omitempty"`
+}
+
+// normalizeServerURL cleans up common ways of writing the ArgoCD address:
+// a bare host gets https://, and trailing slashes are dropped so joining API
+// paths doesn't produce "//api/v1"
+func normalizeServerURL(raw string) string {
+	normalized := strings.TrimSpace(raw)
+	if normalized != "" && !strings.Contains(normalized, "://") {
+		normalized = "https://" + normalized
+	}
+	return strings.TrimRight(normalized, "/")
+}
+
+// validateServerURL checks that an ArgoCD server URL is absolute, so a typo
+// fails at startup instead of as a confusing error on the first request
+func validateServerURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("invalid server URL %q: %w", raw, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid server URL %q: scheme must be http or https", raw)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("invalid server URL %q: missing host", raw)
+	}
+	return nil
+}
+
+// newInstance creates an instance with its own HTTP client and TLS settings
+func newInstance(name string, cfg *ArgocdConfig, clientCfg httpClientConfig) (*argocdInstance, error) {
+	cfg.ServerURL = normalizeServerURL(cfg.ServerURL)
+	if err := validateServerURL(cfg.ServerURL); err != nil {
+		return nil, err
+	}
+	if cfg.AuthTokenFile != "" {
+		token, err := readTokenFile(cfg.AuthTokenFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.AuthToken = token
+	}
+	tlsCfg, err := newTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	transport := clientCfg.newTransport(tlsCfg)
+	return &argocdInstance{
+		name: name,
+		cfg:  cfg,
+		httpClient: &http.Client{
+			Timeout:   clientCfg.timeout,
+			Transport: transport,
+		},
+		streamClient: &http.Client{
+			Transport: transport,
+		},
+	}, nil
+}
+
+// loadInstances reads the ArgoCD servers to connect to. ARGOCD_SERVERS holds
+// a JSON object of named servers, with ARGOCD_DEFAULT_SERVER naming the one
+// used when a tool call doesn't pick one. Without it, the single server
+// configured by ARGOCD_SERVER and friends is used.
+func loadInstances(clientCfg httpClientConfig) (map[string]*argocdInstance, *argocdInstance, error) {
+	raw := os.Getenv("ARGOCD_SERVERS")
+	if raw == "" {
+		inst, err := newInstance(defaultInstanceName, loadArgocdConfig(), clientCfg)
+		if err != nil {
+			return nil, nil, err
+		}
+		return map[string]*argocdInstance{inst.name: inst}, inst, nil
+	}
+
+	var specs map[string]instanceSpec
+	if err := json.Unmarshal([]byte(raw), &specs); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse ARGOCD_SERVERS: %w", err)
+	}
+	if len(specs) == 0 {
+		return nil, nil, fmt.Errorf("ARGOCD_SERVERS defines no servers")
+	}
+
+	instances := make(map[string]*argocdInstance, len(specs))
+	for name, spec := range specs {
+		if spec.ServerURL == "" {
+			return nil, nil, fmt.Errorf("ARGOCD_SERVERS entry %q has no server_url", name)
+		}
+		cfg := spec.ArgocdConfig
+		cfg.Password = spec.Password
+		inst, err := newInstance(name, &cfg, clientCfg)
+		if err != nil {
+			return nil, nil, fmt.Errorf("ARGOCD_SERVERS entry %q: %w", name, err)
+		}
+		instances[name] = inst
+	}
+
+	primaryName := os.Getenv("ARGOCD_DEFAULT_SERVER")
+	if primaryName == "" {
+		if len(instances) > 1 {
+			return nil, nil, fmt.Errorf("ARGOCD_SERVERS defines several servers; set ARGOCD_DEFAULT_SERVER to one of: %s", strings.Join(instanceNames(instances), ", "))
+		}
+		for name := range instances {
+			primaryName = name
+		}
+	}
+	primary, ok := instances[primaryName]
+	if !ok {
+		return nil, nil, fmt.Errorf("ARGOCD_DEFAULT_SERVER %q is not defined in ARGOCD_SERVERS", primaryName)
+	}
+	return instances, primary, nil
+}
+
+// instanceNames returns the names of instances in sorted order
+func instanceNames(instances map[string]*argocdInstance) []string {
+	names := make([]string, 0, len(instances))
+	for name := range instances {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+type instanceKey struct{}
+
+type streamingKey struct{}
+
+// withStreaming marks ctx as belonging to a long-lived streaming request, so
+// it is sent without the ARGOCD_HTTP_TIMEOUT limit. ctx must carry its own
+// deadline or be cancelled by the caller.
+func withStreaming(ctx context.Context) context.Context {
+	return context.WithValue(ctx, streamingKey{}, true)
+}
+
+// client returns the HTTP client to send a request with ctx through
+func (i *argocdInstance) client(ctx context.Context) *http.Client {
+	if streaming, _ := ctx.Value(streamingKey{}).(bool); streaming {
+		return i.streamClient
+	}
+	return i.httpClient
+}
+
+// instance returns the ArgoCD instance selected for ctx, or the primary one
+func (s *MCPServer) instance(ctx context.Context) *argocdInstance {
+	if inst, ok := ctx.Value(instanceKey{}).(*argocdInstance); ok {
+		return inst
+	}
+	return s.primary
+}
+
+// serverURL returns the base URL of the ArgoCD instance selected for ctx
+func (s *MCPServer) serverURL(ctx context.Context) string {
+	return s.instance(ctx).cfg.ServerURL
+}
+
+// instanceMiddleware routes a tool call to the ArgoCD instance named by its
+// argocd_server argument. Everything below it reads the instance from the
+// context, so handlers don't need to know which server they talk to.
+func (s *MCPServer) instanceMiddleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		call, ok := req.(*mcp.CallToolRequest)
+		if !ok || call.Params == nil || len(call.Params.Arguments) == 0 {
+			return next(ctx, method, req)
+		}
+
+		var args struct {
+			Server string `json:"argocd_server"`
+		}
+		if err := json.Unmarshal(call.Params.Arguments, &args); err != nil || args.Server == "" {
+			return next(ctx, method, req)
+		}
+
+		inst, ok := s.instances[args.Server]
+		if !ok {
+			return &mcp.CallToolResult{
+				IsError: true,
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: fmt.Sprintf("unknown %s %q: must be one of %s", instanceArg, args.Server, strings.Join(instanceNames(s.instances), ", "))},
+				},
+			}, nil
+		}
+		return next(context.WithValue(ctx, instanceKey{}, inst), method, req)
+	}
+}
+
+// addTool registers a tool with an input schema inferred from In, so the SDK
+// rejects calls with missing, empty or unknown arguments before the handler
+// runs. Fields without omitempty are required. The schema also accepts the
+// optional argocd_server selector and compact output switch. In core mode
+// only the tools that work without the ArgoCD API server are registered.
+func addTool[In, Out any](s *MCPServer, tool *mcp.Tool, handler mcp.ToolHandlerFor[In, Out]) {
+	if s.core != nil && !coreTools[tool.Name] {
+		return
+	}
+	schema, err := jsonschema.For[In](nil)
+	if err != nil {
+		panic(fmt.Sprintf("tool %q: input schema: %v", tool.Name, err))
+	}
+	if schema.Properties == nil {
+		schema.Properties = map[string]*jsonschema.Schema{}
+	}
+	// A required string is only useful non-empty, so let the SDK reject ""
+	// instead of each handler
+	for _, name := range schema.Required {
+		if prop := schema.Properties[name]; prop != nil && prop.Type == "string" {
+			prop.MinLength = jsonschema.Ptr(1)
+		}
+	}
+	schema.Properties[instanceArg] = &jsonschema.Schema{
+		Type:        "string",
+		Description: "the configured ArgoCD server to use (see ARGOCD_SERVERS); defaults to the primary server",
+	}
+	schema.Properties[compactArg] = &jsonschema.Schema{
+		Type:        "boolean",
+		Description: "return compact JSON without indentation to save tokens (default from MCP_COMPACT_JSON)",
+	}
+	tool.InputSchema = schema
+	mcp.AddTool(s.server, tool, handler)
+}
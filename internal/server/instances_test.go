@@ -0,0 +1,48 @@
+package server
+
+import "testing"
+
+func TestNormalizeServerURL(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{"https://argocd.example.com", "https://argocd.example.com"},
+		{"https://argocd.example.com/", "https://argocd.example.com"},
+		{"https://argocd.example.com//", "https://argocd.example.com"},
+		{"argocd.example.com", "https://argocd.example.com"},
+		{"argocd.example.com/", "https://argocd.example.com"},
+		{"localhost:8080", "https://localhost:8080"},
+		{"http://localhost:8080/", "http://localhost:8080"},
+		{"  https://argocd.example.com/argocd/  ", "https://argocd.example.com/argocd"},
+		{"10.0.0.5", "https://10.0.0.5"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := normalizeServerURL(tt.raw); got != tt.want {
+			t.Errorf("normalizeServerURL(%q) = %q, want %q", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestValidateServerURL(t *testing.T) {
+	tests := []struct {
+		raw     string
+		wantErr bool
+	}{
+		{"https://argocd.example.com", false},
+		{"http://localhost:8080", false},
+		{"https://argocd.example.com/argocd", false},
+		{"https://", true},
+		{"ftp://argocd.example.com", true},
+		{"https://[::1", true},
+		{"", true},
+	}
+
+	for _, tt := range tests {
+		if err := validateServerURL(tt.raw); (err != nil) != tt.wantErr {
+			t.Errorf("validateServerURL(%q) error = %v, want error %v", tt.raw, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+)
+
+const (
+	// defaultMaxResponseBytes caps ArgoCD response bodies when
+	// ARGOCD_MAX_RESPONSE_BYTES is unset
+	defaultMaxResponseBytes = 32 * 1024 * 1024
+
+	// maxErrorBodyBytes caps the body kept from a failed ArgoCD response
+	maxErrorBodyBytes = 64 * 1024
+)
+
+// ResponseTooLargeError is returned when an ArgoCD response body exceeds
+// ARGOCD_MAX_RESPONSE_BYTES
+type ResponseTooLargeError struct {
+	Limit int64
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("ArgoCD response exceeds the %d byte limit (ARGOCD_MAX_RESPONSE_BYTES)", e.Limit)
+}
+
+// loadMaxResponseBytes reads ARGOCD_MAX_RESPONSE_BYTES, falling back to the
+// default for missing or invalid values
+func loadMaxResponseBytes() int64 {
+	value := getEnvWithDefault("ARGOCD_MAX_RESPONSE_BYTES", strconv.Itoa(defaultMaxResponseBytes))
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || n <= 0 {
+		slog.Warn("Invalid ARGOCD_MAX_RESPONSE_BYTES, using default", "value", value, "default", defaultMaxResponseBytes)
+		return defaultMaxResponseBytes
+	}
+	return n
+}
+
+// readLimited reads all of r, failing once more than limit bytes arrive so a
+// huge response can't exhaust memory
+func readLimited(r io.Reader, limit int64) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, &ResponseTooLargeError{Limit: limit}
+	}
+	return data, nil
+}
@@ -0,0 +1,58 @@
+package server
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// endlessReader produces an unbounded stream of bytes, like a misbehaving server
+type endlessReader struct{}
+
+func (endlessReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 'x'
+	}
+	return len(p), nil
+}
+
+func TestReadLimited(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   io.Reader
+		limit  int64
+		want   string
+		tooBig bool
+	}{
+		{"empty", strings.NewReader(""), 10, "", false},
+		{"under limit", strings.NewReader(`{"items":[]}`), 64, `{"items":[]}`, false},
+		{"exactly at limit", strings.NewReader("0123456789"), 10, "0123456789", false},
+		{"one byte over", strings.NewReader("0123456789a"), 10, "", true},
+		{"oversized", bytes.NewReader(make([]byte, 4096)), 1024, "", true},
+		{"endless", endlessReader{}, 1024, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readLimited(tt.body, tt.limit)
+			if tt.tooBig {
+				var tooLarge *ResponseTooLargeError
+				if !errors.As(err, &tooLarge) {
+					t.Fatalf("readLimited() error = %v, want ResponseTooLargeError", err)
+				}
+				if tooLarge.Limit != tt.limit {
+					t.Errorf("ResponseTooLargeError.Limit = %d, want %d", tooLarge.Limit, tt.limit)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readLimited() unexpected error: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("readLimited() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
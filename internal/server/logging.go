@@ -0,0 +1,30 @@
+package server
+
+import (
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// setupLogging installs a leveled logger on stderr as the default, with the
+// level taken from LOG_LEVEL (debug, info, warn or error; default info).
+// Stdout is reserved for the stdio MCP transport. Credentials are scrubbed
+// from every record, whatever the level.
+func setupLogging() {
+	value := getEnvWithDefault("LOG_LEVEL", "info")
+
+	var level slog.Level
+	invalid := level.UnmarshalText([]byte(strings.TrimSpace(value))) != nil
+	if invalid {
+		level = slog.LevelInfo
+	}
+
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
+		Level:       level,
+		ReplaceAttr: redactLogAttr,
+	})))
+
+	if invalid {
+		slog.Warn("Invalid LOG_LEVEL, using info", "value", value)
+	}
+}
@@ -0,0 +1,154 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// defaultLogTailLines is how many lines are requested per container when
+	// the caller doesn't say
+	defaultLogTailLines = 100
+
+	// maxLogLines and maxLogBytes cap the text returned by get_application_logs
+	maxLogLines = 2000
+	maxLogBytes = 256 * 1024
+)
+
+// ApplicationLogsArgs are the arguments for the get_application_logs tool
+type ApplicationLogsArgs struct {
+	Name         string `json:"name" jsonschema:"the application name"`
+	PodName      string `json:"pod_name,omitempty" jsonschema:"only return logs of this pod; omit for all of the application's pods"`
+	Container    string `json:"container,omitempty" jsonschema:"the container to read logs from (default: the pod's first container)"`
+	TailLines    int64  `json:"tail_lines,omitempty" jsonschema:"number of most recent lines per container (default 100)"`
+	SinceSeconds int64  `json:"since_seconds,omitempty" jsonschema:"only return lines newer than this many seconds"`
+}
+
+// logEntry is one message of ArgoCD's streamed log response
+type logEntry struct {
+	Result *struct {
+		Content string `json:"content"`
+		PodName string `json:"podName"`
+		Last    bool   `json:"last"`
+	} `json:"result"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// getApplicationLogs reads the application's pod logs, which ArgoCD streams
+// as one JSON message per line, until the stream ends, a cap is hit or ctx is
+// done. It returns the collected lines and whether they were truncated.
+func (s *MCPServer) getApplicationLogs(ctx context.Context, args ApplicationLogsArgs) ([]string, bool, error) {
+	ctx, cancel := s.callContext(ctx)
+	defer cancel()
+
+	query := url.Values{}
+	query.Set("follow", "false")
+	query.Set("tailLines", strconv.FormatInt(args.TailLines, 10))
+	if args.PodName != "" {
+		query.Set("podName", args.PodName)
+	}
+	if args.Container != "" {
+		query.Set("container", args.Container)
+	}
+	if args.SinceSeconds > 0 {
+		query.Set("sinceSeconds", strconv.FormatInt(args.SinceSeconds, 10))
+	}
+
+	reqURL := s.serverURL(ctx) + applicationPath(args.Name) + "/logs?" + query.Encode()
+	req, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, false, newAPIError(resp)
+	}
+
+	var lines []string
+	size := 0
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), maxLogBytes)
+	for scanner.Scan() {
+		var entry logEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return lines, false, fmt.Errorf("failed to decode log stream: %w", err)
+		}
+		if entry.Error != nil {
+			return lines, false, fmt.Errorf("log stream failed: %s", entry.Error.Message)
+		}
+		if entry.Result == nil {
+			continue
+		}
+		if entry.Result.Last {
+			break
+		}
+
+		line := entry.Result.Content
+		if args.PodName == "" && entry.Result.PodName != "" {
+			line = "[" + entry.Result.PodName + "] " + line
+		}
+		if len(lines) >= maxLogLines || size+len(line) > maxLogBytes {
+			return lines, true, nil
+		}
+		lines = append(lines, line)
+		size += len(line) + 1
+	}
+	if err := scanner.Err(); err != nil {
+		// Whatever arrived before the deadline is still worth returning
+		if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+			return lines, true, nil
+		}
+		return lines, false, fmt.Errorf("failed to read log stream: %w", err)
+	}
+	return lines, false, nil
+}
+
+func (s *MCPServer) handleGetApplicationLogs(ctx context.Context, req *mcp.CallToolRequest, args ApplicationLogsArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	if args.Name == "" {
+		return toolError(fmt.Errorf("name is required"))
+	}
+	if args.TailLines < 0 || args.SinceSeconds < 0 {
+		return toolError(fmt.Errorf("tail_lines and since_seconds must not be negative"))
+	}
+	if args.TailLines == 0 {
+		args.TailLines = defaultLogTailLines
+	}
+
+	lines, truncated, err := s.getApplicationLogs(ctx, args)
+	if err != nil {
+		return toolError(err)
+	}
+
+	text := strings.Join(lines, "\n")
+	if len(lines) == 0 {
+		text = fmt.Sprintf("No log lines returned for application %q", args.Name)
+	}
+	if truncated {
+		text += fmt.Sprintf("\n[output truncated after %d lines]", len(lines))
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: text},
+		},
+	}, nil, nil
+}
@@ -0,0 +1,166 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ManifestResponse represents the rendered manifests for an application
+type ManifestResponse struct {
+	Manifests  []string `json:"manifests"`
+	Namespace  string   `json:"namespace"`
+	Server     string   `json:"server"`
+	Revision   string   `json:"revision"`
+	SourceType string   `json:"sourceType"`
+}
+
+// DiffRevisionsArgs are the arguments for the diff_revisions tool
+type DiffRevisionsArgs struct {
+	Name         string `json:"name" jsonschema:"the application name"`
+	FromRevision string `json:"from_revision" jsonschema:"the base revision (commit SHA, tag or branch)"`
+	ToRevision   string `json:"to_revision" jsonschema:"the revision to compare against the base"`
+}
+
+// ResourceDiff holds the diff for a single modified resource
+type ResourceDiff struct {
+	Resource string `json:"resource"`
+	Diff     string `json:"diff"`
+}
+
+// RevisionDiff is the result of comparing the manifests of two revisions
+type RevisionDiff struct {
+	Application    string            `json:"application"`
+	FromRevision   string            `json:"from_revision"`
+	ToRevision     string            `json:"to_revision"`
+	FromResolved   string            `json:"from_resolved,omitempty"`
+	ToResolved     string            `json:"to_resolved,omitempty"`
+	Errors         map[string]string `json:"errors,omitempty"`
+	Added          []string          `json:"added,omitempty"`
+	Removed        []string          `json:"removed,omitempty"`
+	Modified       []ResourceDiff    `json:"modified,omitempty"`
+	UnchangedCount int               `json:"unchanged_count"`
+}
+
+// getApplicationManifests renders the manifests of an application at the given revision.
+// An empty revision renders the application's target revision.
+func (s *MCPServer) getApplicationManifests(ctx context.Context, name, revision string) (*ManifestResponse, error) {
+	query := url.Values{}
+	if revision != "" {
+		query.Set("revision", revision)
+	}
+
+	var manifests ManifestResponse
+	if err := s.argocdGet(ctx, applicationPath(name)+"/manifests", query, &manifests); err != nil {
+		return nil, err
+	}
+	return &manifests, nil
+}
+
+// parseManifests decodes rendered manifests into objects keyed by resource identity
+func parseManifests(manifests []string) (map[string]map[string]any, error) {
+	objects := make(map[string]map[string]any, len(manifests))
+	for _, manifest := range manifests {
+		var obj map[string]any
+		if err := json.Unmarshal([]byte(manifest), &obj); err != nil {
+			return nil, fmt.Errorf("failed to parse manifest: %w", err)
+		}
+		objects[manifestKey(obj)] = obj
+	}
+	return objects, nil
+}
+
+// manifestKey identifies a Kubernetes object as group/kind/namespace/name
+func manifestKey(obj map[string]any) string {
+	apiVersion, _ := obj["apiVersion"].(string)
+	kind, _ := obj["kind"].(string)
+	metadata, _ := obj["metadata"].(map[string]any)
+	name, _ := metadata["name"].(string)
+	namespace, _ := metadata["namespace"].(string)
+
+	group := ""
+	if i := strings.LastIndex(apiVersion, "/"); i >= 0 {
+		group = apiVersion[:i]
+	}
+	return strings.Join([]string{group, kind, namespace, name}, "/")
+}
+
+func (s *MCPServer) handleDiffRevisions(ctx context.Context, req *mcp.CallToolRequest, args DiffRevisionsArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	if args.Name == "" || args.FromRevision == "" || args.ToRevision == "" {
+		return toolError(fmt.Errorf("name, from_revision and to_revision are required"))
+	}
+
+	result := &RevisionDiff{
+		Application:  args.Name,
+		FromRevision: args.FromRevision,
+		ToRevision:   args.ToRevision,
+	}
+
+	// Render both revisions, recording failures per revision so a bad ref is easy to spot
+	rendered := make(map[string]map[string]map[string]any, 2)
+	for _, rev := range []struct{ label, revision string }{
+		{"from_revision", args.FromRevision},
+		{"to_revision", args.ToRevision},
+	} {
+		manifests, err := s.getApplicationManifests(ctx, args.Name, rev.revision)
+		if err == nil {
+			rendered[rev.label], err = parseManifests(manifests.Manifests)
+		}
+		if err != nil {
+			if result.Errors == nil {
+				result.Errors = make(map[string]string)
+			}
+			result.Errors[rev.label] = fmt.Sprintf("failed to render revision %q: %v", rev.revision, err)
+			continue
+		}
+		if rev.label == "from_revision" {
+			result.FromResolved = manifests.Revision
+		} else {
+			result.ToResolved = manifests.Revision
+		}
+	}
+	if len(result.Errors) > 0 {
+		res, _, err := toolJSON(result)
+		if err != nil {
+			return nil, nil, err
+		}
+		res.IsError = true
+		return res, nil, nil
+	}
+
+	from, to := rendered["from_revision"], rendered["to_revision"]
+	for key, fromObj := range from {
+		toObj, ok := to[key]
+		if !ok {
+			result.Removed = append(result.Removed, key)
+			continue
+		}
+		fromJSON, _ := json.MarshalIndent(fromObj, "", "  ")
+		toJSON, _ := json.MarshalIndent(toObj, "", "  ")
+		if diff := unifiedDiff(args.FromRevision, args.ToRevision, string(fromJSON), string(toJSON)); diff != "" {
+			result.Modified = append(result.Modified, ResourceDiff{Resource: key, Diff: diff})
+		} else {
+			result.UnchangedCount++
+		}
+	}
+	for key := range to {
+		if _, ok := from[key]; !ok {
+			result.Added = append(result.Added, key)
+		}
+	}
+
+	sort.Strings(result.Added)
+	sort.Strings(result.Removed)
+	sort.Slice(result.Modified, func(i, j int) bool {
+		return result.Modified[i].Resource < result.Modified[j].Resource
+	})
+
+	return toolJSON(result)
+}
@@ -0,0 +1,111 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Metrics are registered once per process, so creating several MCPServers
+// (as tests do) doesn't register them twice
+var (
+	mcpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "argocd_mcp_requests_total",
+		Help: "MCP requests handled, by MCP method and tool name or resource URI.",
+	}, []string{"method", "name"})
+
+	argocdErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "argocd_mcp_argocd_errors_total",
+		Help: "Failed ArgoCD API calls, by HTTP status code (\"network\" when no response arrived).",
+	}, []string{"status_code"})
+
+	argocdCallDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "argocd_mcp_argocd_call_duration_seconds",
+		Help:    "Latency of ArgoCD API calls, retries included.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"call"})
+)
+
+// metricsMiddleware counts incoming MCP requests per tool and resource
+func (s *MCPServer) metricsMiddleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		name := ""
+		switch r := req.(type) {
+		case *mcp.CallToolRequest:
+			if r.Params != nil {
+				name = r.Params.Name
+			}
+		case *mcp.ReadResourceRequest:
+			if r.Params != nil {
+				name = resourceMetricLabel(r.Params.URI)
+			}
+		}
+		mcpRequests.WithLabelValues(method, name).Inc()
+		return next(ctx, method, req)
+	}
+}
+
+// resourceMetricLabel names a resource read for the requests metric by the
+// resource or template it matches, so neither query variants nor application
+// names multiply series. URIs that match nothing share one label.
+func resourceMetricLabel(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "other"
+	}
+	u.RawQuery = ""
+	switch base := u.String(); {
+	case base == "argocd://applications", base == "argocd://clusters":
+		return base
+	case strings.HasPrefix(base, applicationResourcePrefix):
+		return applicationResourcePrefix + "{name}"
+	}
+	return "other"
+}
+
+// observeArgocdCall records the latency of an ArgoCD call started at start
+func observeArgocdCall(call string, start time.Time) {
+	argocdCallDuration.WithLabelValues(call).Observe(time.Since(start).Seconds())
+}
+
+// countArgocdError records a failed ArgoCD call from its response or error
+func countArgocdError(resp *http.Response, err error) {
+	switch {
+	case err != nil:
+		argocdErrors.WithLabelValues("network").Inc()
+	case resp.StatusCode >= http.StatusBadRequest:
+		argocdErrors.WithLabelValues(strconv.Itoa(resp.StatusCode)).Inc()
+	}
+}
+
+// serveMetrics exposes Prometheus metrics at /metrics on addr until ctx is
+// cancelled. A failing metrics endpoint is logged but doesn't stop the server.
+func serveMetrics(ctx context.Context, addr string) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	httpServer := &http.Server{Addr: addr, Handler: mux}
+
+	go func() {
+		slog.Info("Serving Prometheus metrics at /metrics", "addr", addr)
+		if err := httpServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("Metrics endpoint failed", "addr", addr, "error", err)
+		}
+	}()
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), httpShutdownTimeout)
+		defer cancel()
+		httpServer.Shutdown(shutdownCtx)
+	}()
+}
@@ -0,0 +1,23 @@
+package server
+
+import "testing"
+
+func TestResourceMetricLabel(t *testing.T) {
+	tests := []struct {
+		uri  string
+		want string
+	}{
+		{"argocd://applications", "argocd://applications"},
+		{"argocd://applications?view=names&format=ndjson", "argocd://applications"},
+		{"argocd://applications/guestbook", "argocd://applications/{name}"},
+		{"argocd://applications/checkout?view=summary", "argocd://applications/{name}"},
+		{"argocd://clusters", "argocd://clusters"},
+		{"argocd://projects/secret", "other"},
+		{"%zz", "other"},
+	}
+	for _, tt := range tests {
+		if got := resourceMetricLabel(tt.uri); got != tt.want {
+			t.Errorf("resourceMetricLabel(%q) = %q, want %q", tt.uri, got, tt.want)
+		}
+	}
+}
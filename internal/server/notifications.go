@@ -0,0 +1,62 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// notificationsConfigMap holds the notification services and templates
+const notificationsConfigMap = "argocd-notifications-cm"
+
+// NotificationService is a notification service (e.g. slack or email)
+// configured in the notifications ConfigMap
+type NotificationService struct {
+	Name string `json:"name"`
+}
+
+// NotificationServices is the result of the get_notifications_services tool
+type NotificationServices struct {
+	Services []NotificationService `json:"services"`
+	Message  string                `json:"message,omitempty"`
+}
+
+func (s *MCPServer) handleGetNotificationsServices(ctx context.Context, req *mcp.CallToolRequest, args struct{}) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	var list struct {
+		Items []NotificationService `json:"items"`
+	}
+	err := s.argocdGet(ctx, "/api/v1/notifications/services", nil, &list)
+	var apiErr *APIError
+	switch {
+	case err == nil:
+	case errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound && strings.Contains(apiErr.Message, notificationsConfigMap):
+		// Some versions report the missing ConfigMap instead of returning
+		// an empty list
+	case errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusNotFound || apiErr.StatusCode == http.StatusNotImplemented):
+		// Versions before notifications moved into ArgoCD don't serve the
+		// endpoint at all
+		return toolJSON(NotificationServices{
+			Services: []NotificationService{},
+			Message:  "This ArgoCD instance does not expose notifications over its API.",
+		})
+	default:
+		return toolError(err)
+	}
+
+	result := NotificationServices{Services: list.Items}
+	if len(result.Services) == 0 {
+		result.Services = []NotificationService{}
+		result.Message = "No notification services are configured in " + notificationsConfigMap + "."
+	}
+	sort.Slice(result.Services, func(i, j int) bool {
+		return result.Services[i].Name < result.Services[j].Name
+	})
+
+	return toolJSON(result)
+}
@@ -0,0 +1,120 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// TerminateOperationArgs are the arguments for the terminate_operation tool
+type TerminateOperationArgs struct {
+	Name string `json:"name" jsonschema:"the application name"`
+}
+
+// noOperationInProgress reports whether err is ArgoCD refusing to terminate
+// because nothing is running. ArgoCD answers with a failed precondition,
+// which surfaces as a 400 (or a 500 on older versions).
+func noOperationInProgress(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	if apiErr.StatusCode != http.StatusBadRequest && apiErr.StatusCode != http.StatusInternalServerError {
+		return false
+	}
+	return strings.Contains(strings.ToLower(apiErr.Message+apiErr.Body), "no operation is in progress")
+}
+
+func (s *MCPServer) handleTerminateOperation(ctx context.Context, req *mcp.CallToolRequest, args TerminateOperationArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	if args.Name == "" {
+		return toolError(fmt.Errorf("name is required"))
+	}
+
+	if err := s.argocdDo(ctx, "DELETE", applicationPath(args.Name)+"/operation", nil, nil, nil); err != nil {
+		if noOperationInProgress(err) {
+			return toolError(fmt.Errorf("application %q has no operation in progress to terminate", args.Name))
+		}
+		return toolError(err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: fmt.Sprintf("Termination requested for the running operation of application %q", args.Name)},
+		},
+	}, nil, nil
+}
+
+// OperationState is the state of an application's current or last operation
+type OperationState struct {
+	Application     string  `json:"application"`
+	InProgress      bool    `json:"in_progress"`
+	Phase           string  `json:"phase,omitempty"`
+	Message         string  `json:"message,omitempty"`
+	StartedAt       string  `json:"started_at,omitempty"`
+	FinishedAt      string  `json:"finished_at,omitempty"`
+	DurationSeconds float64 `json:"duration_seconds,omitempty"`
+	Revision        string  `json:"revision,omitempty"`
+	InitiatedBy     string  `json:"initiated_by,omitempty"`
+	Automated       bool    `json:"automated,omitempty"`
+	RetryCount      int64   `json:"retry_count,omitempty"`
+}
+
+// operationState summarizes the operation state of app. A running
+// operation's duration is measured up to now.
+func operationState(app *ArgocdApplication, now time.Time) *OperationState {
+	op := app.Status.OperationState
+	state := &OperationState{
+		Application: app.Metadata.Name,
+		InProgress:  op.Phase != "" && !operationFinished(op.Phase),
+		Phase:       op.Phase,
+		Message:     op.Message,
+		StartedAt:   op.StartedAt,
+		FinishedAt:  op.FinishedAt,
+		RetryCount:  op.RetryCount,
+	}
+	if op.Phase == "" {
+		state.Message = "no operation has run on this application"
+	}
+
+	if started, err := time.Parse(time.RFC3339, op.StartedAt); err == nil {
+		end := now
+		if finished, err := time.Parse(time.RFC3339, op.FinishedAt); err == nil {
+			end = finished
+		}
+		state.DurationSeconds = end.Sub(started).Round(time.Second).Seconds()
+	}
+
+	if op.SyncResult != nil {
+		state.Revision = op.SyncResult.Revision
+	}
+	if op.Operation != nil {
+		state.InitiatedBy = op.Operation.InitiatedBy.Username
+		state.Automated = op.Operation.InitiatedBy.Automated
+		if state.Revision == "" && op.Operation.Sync != nil {
+			state.Revision = op.Operation.Sync.Revision
+		}
+	}
+	return state
+}
+
+func (s *MCPServer) handleGetOperationState(ctx context.Context, req *mcp.CallToolRequest, args ApplicationNameArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	if args.Name == "" {
+		return toolError(fmt.Errorf("name is required"))
+	}
+
+	app, err := s.getApplication(ctx, args.Name)
+	if err != nil {
+		return toolError(err)
+	}
+
+	return toolJSON(operationState(app, time.Now()))
+}
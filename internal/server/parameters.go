@@ -0,0 +1,119 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// SourceParameters are the overrides set on one source of an application
+type SourceParameters struct {
+	RepoURL string `json:"repo_url"`
+	Path    string `json:"path,omitempty"`
+	Chart   string `json:"chart,omitempty"`
+	// Parameters holds "helm" and/or "kustomize" overrides; it is empty for
+	// plain directory sources
+	Parameters map[string]any `json:"parameters"`
+}
+
+// ApplicationParameters is the result of the get_application_parameters tool
+type ApplicationParameters struct {
+	Application string             `json:"application"`
+	Sources     []SourceParameters `json:"sources"`
+}
+
+// helmParameters returns the Helm overrides that are set, keyed in snake_case
+func helmParameters(helm *HelmSource) map[string]any {
+	params := map[string]any{}
+	if helm.ReleaseName != "" {
+		params["release_name"] = helm.ReleaseName
+	}
+	if len(helm.ValueFiles) > 0 {
+		params["value_files"] = helm.ValueFiles
+	}
+	if helm.Values != "" {
+		params["values"] = helm.Values
+	}
+	if len(helm.ValuesObject) > 0 {
+		params["values_object"] = helm.ValuesObject
+	}
+	if len(helm.Parameters) > 0 {
+		set := make(map[string]string, len(helm.Parameters))
+		for _, p := range helm.Parameters {
+			set[p.Name] = p.Value
+		}
+		params["parameters"] = set
+	}
+	return params
+}
+
+// kustomizeParameters returns the Kustomize overrides that are set, keyed in snake_case
+func kustomizeParameters(kustomize *KustomizeSource) map[string]any {
+	params := map[string]any{}
+	if kustomize.NamePrefix != "" {
+		params["name_prefix"] = kustomize.NamePrefix
+	}
+	if kustomize.NameSuffix != "" {
+		params["name_suffix"] = kustomize.NameSuffix
+	}
+	if kustomize.Namespace != "" {
+		params["namespace"] = kustomize.Namespace
+	}
+	if len(kustomize.Images) > 0 {
+		params["images"] = kustomize.Images
+	}
+	if len(kustomize.CommonLabels) > 0 {
+		params["common_labels"] = kustomize.CommonLabels
+	}
+	if len(kustomize.CommonAnnotations) > 0 {
+		params["common_annotations"] = kustomize.CommonAnnotations
+	}
+	return params
+}
+
+// sourceParameters collects the overrides of a single application source
+func sourceParameters(source *ApplicationSource) SourceParameters {
+	result := SourceParameters{
+		RepoURL:    source.RepoURL,
+		Path:       source.Path,
+		Chart:      source.Chart,
+		Parameters: map[string]any{},
+	}
+	if source.Helm != nil {
+		if helm := helmParameters(source.Helm); len(helm) > 0 {
+			result.Parameters["helm"] = helm
+		}
+	}
+	if source.Kustomize != nil {
+		if kustomize := kustomizeParameters(source.Kustomize); len(kustomize) > 0 {
+			result.Parameters["kustomize"] = kustomize
+		}
+	}
+	return result
+}
+
+func (s *MCPServer) handleGetApplicationParameters(ctx context.Context, req *mcp.CallToolRequest, args ApplicationNameArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	if args.Name == "" {
+		return toolError(fmt.Errorf("name is required"))
+	}
+
+	app, err := s.getApplication(ctx, args.Name)
+	if err != nil {
+		return toolError(err)
+	}
+
+	sources := app.sources()
+
+	result := &ApplicationParameters{
+		Application: app.Metadata.Name,
+		Sources:     make([]SourceParameters, len(sources)),
+	}
+	for i := range sources {
+		result.Sources[i] = sourceParameters(&sources[i])
+	}
+
+	return toolJSON(result)
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// PatchApplicationArgs are the arguments for the patch_application tool
+type PatchApplicationArgs struct {
+	Name      string `json:"name" jsonschema:"the application name"`
+	Patch     string `json:"patch" jsonschema:"the patch as JSON text, e.g. {\"spec\":{\"source\":{\"targetRevision\":\"v1.2.0\"}}} for a merge patch or [{\"op\":\"replace\",\"path\":\"/spec/source/targetRevision\",\"value\":\"v1.2.0\"}] for a JSON patch"`
+	PatchType string `json:"patch_type,omitempty" jsonschema:"merge (RFC 7386 JSON merge patch, the default) or json (RFC 6902 JSON patch)"`
+}
+
+// validatePatch checks that patch is well-formed JSON of the shape patchType
+// expects, so mistakes are reported before anything reaches ArgoCD
+func validatePatch(patch, patchType string) error {
+	if !json.Valid([]byte(patch)) {
+		return fmt.Errorf("patch is not valid JSON")
+	}
+
+	switch patchType {
+	case "merge":
+		var obj map[string]any
+		if err := json.Unmarshal([]byte(patch), &obj); err != nil || obj == nil {
+			return fmt.Errorf("a merge patch must be a JSON object")
+		}
+	case "json":
+		var ops []struct {
+			Op   string `json:"op"`
+			Path string `json:"path"`
+		}
+		if err := json.Unmarshal([]byte(patch), &ops); err != nil {
+			return fmt.Errorf("a JSON patch must be an array of operations")
+		}
+		if len(ops) == 0 {
+			return fmt.Errorf("the JSON patch has no operations")
+		}
+		for i, op := range ops {
+			if op.Op == "" || op.Path == "" {
+				return fmt.Errorf("JSON patch operation %d needs an op and a path", i+1)
+			}
+		}
+	default:
+		return fmt.Errorf("invalid patch_type %q: must be merge or json", patchType)
+	}
+	return nil
+}
+
+func (s *MCPServer) handlePatchApplication(ctx context.Context, req *mcp.CallToolRequest, args PatchApplicationArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	if args.Name == "" {
+		return toolError(fmt.Errorf("name is required"))
+	}
+	patchType := firstNonEmpty(args.PatchType, "merge")
+	if err := validatePatch(args.Patch, patchType); err != nil {
+		return toolError(err)
+	}
+
+	app, err := s.patchApplication(ctx, args.Name, json.RawMessage(args.Patch), patchType)
+	if err != nil {
+		return toolError(err)
+	}
+
+	return toolJSON(app)
+}
@@ -0,0 +1,153 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// SyncWindowsState is ArgoCD's evaluation of the sync windows for an app
+type SyncWindowsState struct {
+	AssignedWindows []SyncWindow `json:"assignedWindows,omitempty"`
+	ActiveWindows   []SyncWindow `json:"activeWindows,omitempty"`
+	CanSync         bool         `json:"canSync"`
+}
+
+// EffectiveAutomation is the automated sync behavior of an app
+type EffectiveAutomation struct {
+	Enabled    bool  `json:"enabled"`
+	Prune      bool  `json:"prune"`
+	SelfHeal   bool  `json:"self_heal"`
+	AllowEmpty bool  `json:"allow_empty"`
+	RetryLimit int64 `json:"retry_limit,omitempty"`
+}
+
+// EffectiveWindows summarizes the sync windows that apply to an app
+type EffectiveWindows struct {
+	Assigned []SyncWindow `json:"assigned"`
+	Active   []SyncWindow `json:"active"`
+	CanSync  bool         `json:"can_sync"`
+	Error    string       `json:"error,omitempty"`
+}
+
+// EffectiveResources summarizes which resource kinds the project lets the app manage
+type EffectiveResources struct {
+	ClusterScopedAllowed   []GroupKind `json:"cluster_scoped_allowed"`
+	ClusterScopedDenied    []GroupKind `json:"cluster_scoped_denied"`
+	NamespaceScopedAllowed []GroupKind `json:"namespace_scoped_allowed,omitempty"`
+	NamespaceScopedDenied  []GroupKind `json:"namespace_scoped_denied,omitempty"`
+}
+
+// EffectivePolicy is the result of the get_effective_sync_policy tool
+type EffectivePolicy struct {
+	Application          string              `json:"application"`
+	Project              string              `json:"project"`
+	Automated            EffectiveAutomation `json:"automated"`
+	SyncOptions          []string            `json:"sync_options"`
+	SourcePermitted      bool                `json:"source_permitted"`
+	DestinationPermitted bool                `json:"destination_permitted"`
+	SyncWindows          EffectiveWindows    `json:"sync_windows"`
+	Resources            EffectiveResources  `json:"resources"`
+	Notes                []string            `json:"notes"`
+}
+
+// getSyncWindows asks ArgoCD which of the project's sync windows apply to an
+// app and whether they currently allow a sync
+func (s *MCPServer) getSyncWindows(ctx context.Context, name string) (*SyncWindowsState, error) {
+	var state SyncWindowsState
+	if err := s.argocdGet(ctx, applicationPath(name)+"/syncwindows", nil, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+func (s *MCPServer) handleGetEffectiveSyncPolicy(ctx context.Context, req *mcp.CallToolRequest, args ApplicationNameArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	if args.Name == "" {
+		return toolError(fmt.Errorf("name is required"))
+	}
+
+	app, err := s.getApplication(ctx, args.Name)
+	if err != nil {
+		return toolError(err)
+	}
+	projectName := firstNonEmpty(app.Spec.Project, "default")
+	project, err := s.getProject(ctx, projectName)
+	if err != nil {
+		return toolError(fmt.Errorf("failed to get project %q: %w", projectName, err))
+	}
+
+	policy := &EffectivePolicy{
+		Application: app.Metadata.Name,
+		Project:     projectName,
+		SyncOptions: app.Spec.SyncPolicy.SyncOptions,
+		Notes:       []string{},
+	}
+	if policy.SyncOptions == nil {
+		policy.SyncOptions = []string{}
+	}
+
+	if automated := app.Spec.SyncPolicy.Automated; automated != nil {
+		policy.Automated = EffectiveAutomation{
+			Enabled:    true,
+			Prune:      automated.Prune,
+			SelfHeal:   automated.SelfHeal,
+			AllowEmpty: automated.AllowEmpty,
+		}
+	}
+	if retry := app.Spec.SyncPolicy.Retry; retry != nil {
+		policy.Automated.RetryLimit = retry.Limit
+	}
+
+	dest := app.Spec.Destination
+	denied := deniedSources(project.Spec.SourceRepos, app)
+	policy.SourcePermitted = len(denied) == 0
+	policy.DestinationPermitted = destinationPermitted(project.Spec.Destinations, dest.Server, dest.Name, dest.Namespace)
+	for _, repo := range denied {
+		policy.Notes = append(policy.Notes, fmt.Sprintf("The source repo %q is not in project %q's sourceRepos, so ArgoCD will refuse to sync.", repo, projectName))
+	}
+	if !policy.DestinationPermitted {
+		policy.Notes = append(policy.Notes, fmt.Sprintf("The destination is not in project %q's destinations, so ArgoCD will refuse to sync.", projectName))
+	}
+
+	policy.SyncWindows = EffectiveWindows{Assigned: []SyncWindow{}, Active: []SyncWindow{}, CanSync: true}
+	if windows, err := s.getSyncWindows(ctx, args.Name); err != nil {
+		policy.SyncWindows.Error = err.Error()
+	} else {
+		policy.SyncWindows.CanSync = windows.CanSync
+		if windows.AssignedWindows != nil {
+			policy.SyncWindows.Assigned = windows.AssignedWindows
+		}
+		if windows.ActiveWindows != nil {
+			policy.SyncWindows.Active = windows.ActiveWindows
+		}
+		if !windows.CanSync {
+			note := "A sync window currently blocks syncs"
+			if policy.Automated.Enabled {
+				note += ", including automated ones"
+			}
+			policy.Notes = append(policy.Notes, note+".")
+		}
+	}
+
+	// An empty cluster resource whitelist means no cluster-scoped resources at
+	// all, while an empty namespace whitelist means every namespaced kind
+	spec := project.Spec
+	policy.Resources = EffectiveResources{
+		ClusterScopedAllowed:   spec.ClusterResourceWhitelist,
+		ClusterScopedDenied:    spec.ClusterResourceBlacklist,
+		NamespaceScopedAllowed: spec.NamespaceResourceWhitelist,
+		NamespaceScopedDenied:  spec.NamespaceResourceBlacklist,
+	}
+	if policy.Resources.ClusterScopedAllowed == nil {
+		policy.Resources.ClusterScopedAllowed = []GroupKind{}
+		policy.Notes = append(policy.Notes, "The project allows no cluster-scoped resources (empty clusterResourceWhitelist), so kinds like Namespace or ClusterRole can't be created.")
+	}
+	if policy.Resources.ClusterScopedDenied == nil {
+		policy.Resources.ClusterScopedDenied = []GroupKind{}
+	}
+
+	return toolJSON(policy)
+}
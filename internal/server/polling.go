@@ -0,0 +1,92 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultPollInterval is used when POLL_INTERVAL is unset or invalid
+	defaultPollInterval = 2 * time.Second
+
+	// minPollInterval is the floor for any configured interval, so a
+	// misconfiguration can't make polling tools hammer ArgoCD
+	minPollInterval = 1 * time.Second
+
+	pollIntervalEnv = "POLL_INTERVAL"
+)
+
+// PollConfig holds the intervals used by tools that poll ArgoCD
+type PollConfig struct {
+	Default   time.Duration            `json:"default"`
+	Overrides map[string]time.Duration `json:"overrides,omitempty"`
+}
+
+// loadPollConfig reads POLL_INTERVAL and per-tool POLL_INTERVAL_<TOOL_NAME>
+// overrides (e.g. POLL_INTERVAL_WAIT_FOR_SYNC=5s) from the environment
+func loadPollConfig() *PollConfig {
+	cfg := &PollConfig{
+		Default:   parsePollInterval(pollIntervalEnv, os.Getenv(pollIntervalEnv), defaultPollInterval),
+		Overrides: map[string]time.Duration{},
+	}
+
+	for _, kv := range os.Environ() {
+		key, value, _ := strings.Cut(kv, "=")
+		tool, ok := strings.CutPrefix(key, pollIntervalEnv+"_")
+		if !ok || tool == "" {
+			continue
+		}
+		cfg.Overrides[strings.ToLower(tool)] = parsePollInterval(key, value, cfg.Default)
+	}
+
+	return cfg
+}
+
+// parsePollInterval parses a duration, falling back on invalid input and
+// clamping to minPollInterval
+func parsePollInterval(key, value string, fallback time.Duration) time.Duration {
+	if value == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		slog.Warn("Invalid poll interval, using fallback", "key", key, "value", value, "fallback", fallback, "error", err)
+		return fallback
+	}
+	if d < minPollInterval {
+		slog.Warn("Poll interval is below the minimum", "key", key, "value", d, "minimum", minPollInterval)
+		return minPollInterval
+	}
+	return d
+}
+
+// pollInterval returns the interval the named tool should poll at
+func (s *MCPServer) pollInterval(tool string) time.Duration {
+	if d, ok := s.pollCfg.Overrides[tool]; ok {
+		return d
+	}
+	return s.pollCfg.Default
+}
+
+// poll calls check every interval until it reports done, returns an error,
+// or ctx is cancelled. check is called once immediately.
+func poll(ctx context.Context, interval time.Duration, check func(ctx context.Context) (bool, error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		done, err := check(ctx)
+		if err != nil || done {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
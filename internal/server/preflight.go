@@ -0,0 +1,205 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Outcomes of a single preflight check
+const (
+	checkPass    = "pass"
+	checkFail    = "fail"
+	checkWarn    = "warn"
+	checkUnknown = "unknown"
+)
+
+// PreflightCheck is the outcome of one sync readiness check
+type PreflightCheck struct {
+	Check  string `json:"check"`
+	Status string `json:"status"`
+	Detail string `json:"detail"`
+}
+
+// SyncReadiness is the result of the check_sync_readiness tool
+type SyncReadiness struct {
+	Application string           `json:"application"`
+	Ready       bool             `json:"ready"`
+	Destination string           `json:"destination"`
+	Namespace   string           `json:"namespace"`
+	Automated   bool             `json:"automated"`
+	SyncOptions []string         `json:"sync_options"`
+	Checks      []PreflightCheck `json:"checks"`
+}
+
+// hasSyncOption reports whether an app sets the given sync option to true
+func hasSyncOption(app *ArgocdApplication, option string) bool {
+	return slices.Contains(app.Spec.SyncPolicy.SyncOptions, option+"=true")
+}
+
+func (s *MCPServer) handleCheckSyncReadiness(ctx context.Context, req *mcp.CallToolRequest, args ApplicationNameArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	if args.Name == "" {
+		return toolError(fmt.Errorf("name is required"))
+	}
+
+	app, err := s.getApplication(ctx, args.Name)
+	if err != nil {
+		return toolError(err)
+	}
+
+	dest := app.Spec.Destination
+	result := &SyncReadiness{
+		Application: app.Metadata.Name,
+		Ready:       true,
+		Destination: dest.Server,
+		Namespace:   dest.Namespace,
+		Automated:   app.Spec.SyncPolicy.Automated != nil,
+		SyncOptions: app.Spec.SyncPolicy.SyncOptions,
+	}
+	if result.Destination == "" {
+		result.Destination = dest.Name
+	}
+	if result.SyncOptions == nil {
+		result.SyncOptions = []string{}
+	}
+	addCheck := func(check, status, detail string) {
+		if status == checkFail {
+			result.Ready = false
+		}
+		result.Checks = append(result.Checks, PreflightCheck{Check: check, Status: status, Detail: detail})
+	}
+
+	// Render the target manifests to learn which API groups the sync needs
+	var objects map[string]map[string]any
+	manifests, err := s.getApplicationManifests(ctx, args.Name, "")
+	if err == nil {
+		objects, err = parseManifests(manifests.Manifests)
+	}
+	if err != nil {
+		addCheck("manifests", checkFail, "target manifests could not be generated: "+err.Error())
+	} else {
+		addCheck("manifests", checkPass, fmt.Sprintf("%d target resources generated", len(objects)))
+	}
+
+	// Find the destination cluster's served API versions
+	var cluster *Cluster
+	if dest.Server != "" {
+		var c Cluster
+		if err := s.argocdGet(ctx, clusterPath(dest.Server), nil, &c); err != nil {
+			addCheck("cluster", checkUnknown, "destination cluster info unavailable: "+err.Error())
+		} else {
+			cluster = &c
+		}
+	} else if clusters, err := s.getClusters(ctx); err != nil {
+		addCheck("cluster", checkUnknown, "destination cluster info unavailable: "+err.Error())
+	} else {
+		for i := range clusters.Items {
+			if clusters.Items[i].Name == dest.Name {
+				cluster = &clusters.Items[i]
+			}
+		}
+		if cluster == nil {
+			addCheck("cluster", checkFail, fmt.Sprintf("destination cluster %q is not registered", dest.Name))
+		}
+	}
+	if cluster != nil {
+		status, message, _ := cluster.connectionState()
+		if status == "Failed" {
+			addCheck("cluster", checkFail, "destination cluster connection failed: "+message)
+		} else {
+			addCheck("cluster", checkPass, fmt.Sprintf("destination cluster connection status %q", status))
+		}
+	}
+
+	// Check that every API version the manifests use is served by the
+	// cluster or is defined by a CRD shipped in the same sync
+	if objects != nil && cluster != nil {
+		if len(cluster.Info.APIVersions) == 0 {
+			addCheck("crds", checkUnknown, "the cluster has not reported its API versions yet")
+		} else {
+			served := make(map[string]bool, len(cluster.Info.APIVersions))
+			for _, v := range cluster.Info.APIVersions {
+				served[v] = true
+			}
+			shipped := map[string]bool{}
+			for _, obj := range objects {
+				if kind, _ := obj["kind"].(string); kind == "CustomResourceDefinition" {
+					spec, _ := obj["spec"].(map[string]any)
+					if group, _ := spec["group"].(string); group != "" {
+						shipped[group] = true
+					}
+				}
+			}
+
+			missing := map[string]bool{}
+			for _, obj := range objects {
+				apiVersion, _ := obj["apiVersion"].(string)
+				group, _, found := strings.Cut(apiVersion, "/")
+				if apiVersion == "" || served[apiVersion] || (found && shipped[group]) {
+					continue
+				}
+				kind, _ := obj["kind"].(string)
+				missing[apiVersion+" "+kind] = true
+			}
+			if len(missing) == 0 {
+				addCheck("crds", checkPass, "all resource types are served by the destination cluster")
+			} else {
+				kinds := make([]string, 0, len(missing))
+				for k := range missing {
+					kinds = append(kinds, k)
+				}
+				sort.Strings(kinds)
+				addCheck("crds", checkFail, "resource types not served by the destination cluster (missing CRDs?): "+strings.Join(kinds, ", "))
+			}
+		}
+	}
+
+	// The destination namespace must exist unless ArgoCD is asked to create it
+	switch {
+	case dest.Namespace == "":
+		addCheck("namespace", checkPass, "no destination namespace set; resources use their own namespaces")
+	case hasSyncOption(app, "CreateNamespace"):
+		addCheck("namespace", checkPass, "CreateNamespace=true is set, ArgoCD will create the namespace")
+	default:
+		if objects != nil {
+			if _, ok := objects["/Namespace//"+dest.Namespace]; ok {
+				addCheck("namespace", checkPass, "the namespace is part of the application's manifests")
+				break
+			}
+		}
+		tree, err := s.getResourceTree(ctx, args.Name)
+		if err != nil {
+			addCheck("namespace", checkUnknown, "could not inspect live resources: "+err.Error())
+			break
+		}
+		found := false
+		for _, node := range tree.Nodes {
+			if node.Namespace == dest.Namespace {
+				found = true
+				break
+			}
+		}
+		if found {
+			addCheck("namespace", checkPass, "live resources already exist in the namespace")
+		} else {
+			addCheck("namespace", checkWarn, fmt.Sprintf("namespace %q could not be confirmed and CreateNamespace=true is not set; the sync fails if it doesn't exist", dest.Namespace))
+		}
+	}
+
+	// Surface conditions that will keep a sync from succeeding
+	if errs := reconcileErrors(app); len(errs) > 0 {
+		for _, cond := range errs {
+			addCheck("conditions", checkFail, cond.Type+": "+cond.Message)
+		}
+	} else {
+		addCheck("conditions", checkPass, "no SyncError or ComparisonError conditions")
+	}
+
+	return toolJSON(result)
+}
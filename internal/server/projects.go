@@ -0,0 +1,341 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ProjectDestination is a destination an AppProject permits
+type ProjectDestination struct {
+	Server    string `json:"server,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// GroupKind identifies a Kubernetes resource type in project allow/deny lists
+type GroupKind struct {
+	Group string `json:"group"`
+	Kind  string `json:"kind"`
+}
+
+// SyncWindow is a time window in which syncs are allowed or denied
+type SyncWindow struct {
+	Kind         string   `json:"kind"`
+	Schedule     string   `json:"schedule"`
+	Duration     string   `json:"duration"`
+	Applications []string `json:"applications,omitempty"`
+	Namespaces   []string `json:"namespaces,omitempty"`
+	Clusters     []string `json:"clusters,omitempty"`
+	ManualSync   bool     `json:"manualSync,omitempty"`
+	TimeZone     string   `json:"timeZone,omitempty"`
+}
+
+// Project represents an ArgoCD AppProject
+type Project struct {
+	Metadata struct {
+		Name string `json:"name"`
+	} `json:"metadata"`
+	Spec struct {
+		Description                string               `json:"description,omitempty"`
+		SourceRepos                []string             `json:"sourceRepos,omitempty"`
+		Destinations               []ProjectDestination `json:"destinations,omitempty"`
+		SyncWindows                []SyncWindow         `json:"syncWindows,omitempty"`
+		ClusterResourceWhitelist   []GroupKind          `json:"clusterResourceWhitelist,omitempty"`
+		ClusterResourceBlacklist   []GroupKind          `json:"clusterResourceBlacklist,omitempty"`
+		NamespaceResourceWhitelist []GroupKind          `json:"namespaceResourceWhitelist,omitempty"`
+		NamespaceResourceBlacklist []GroupKind          `json:"namespaceResourceBlacklist,omitempty"`
+	} `json:"spec"`
+}
+
+// ProjectList represents a list of ArgoCD AppProjects
+type ProjectList struct {
+	Items []Project `json:"items"`
+}
+
+// getProject fetches a single AppProject by name
+func (s *MCPServer) getProject(ctx context.Context, name string) (*Project, error) {
+	var project Project
+	if err := s.argocdGet(ctx, "/api/v1/projects/"+url.PathEscape(name), nil, &project); err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+func (s *MCPServer) handleListProjects(ctx context.Context, req *mcp.CallToolRequest, args struct{}) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	var projects ProjectList
+	if err := s.argocdGet(ctx, "/api/v1/projects", nil, &projects); err != nil {
+		return toolError(err)
+	}
+	if projects.Items == nil {
+		projects.Items = []Project{}
+	}
+
+	return toolJSON(projects)
+}
+
+// CreateProjectArgs are the arguments for the create_project tool
+type CreateProjectArgs struct {
+	Name         string               `json:"name" jsonschema:"the project name"`
+	Description  string               `json:"description,omitempty" jsonschema:"a human-readable description of the project"`
+	SourceRepos  []string             `json:"source_repos,omitempty" jsonschema:"Git repository URLs or globs applications may deploy from, e.g. https://github.com/org/*"`
+	Destinations []ProjectDestination `json:"destinations" jsonschema:"clusters (by server URL or name) and namespaces applications may deploy to; globs such as * are allowed"`
+}
+
+// validate checks the fields ArgoCD needs before anything is sent
+func (a *CreateProjectArgs) validate() error {
+	if a.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if len(a.Destinations) == 0 {
+		return fmt.Errorf("at least one destination is required")
+	}
+	for i, dest := range a.Destinations {
+		if dest.Server == "" && dest.Name == "" {
+			return fmt.Errorf("destination %d: server or name is required", i+1)
+		}
+		if dest.Namespace == "" {
+			return fmt.Errorf("destination %d: namespace is required (use * for any)", i+1)
+		}
+	}
+	return nil
+}
+
+// projectExists reports whether err is ArgoCD refusing to create a project
+// that already exists. Newer versions answer 409; older ones return a 400
+// saying the existing spec differs.
+func projectExists(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	if errors.Is(err, ErrConflict) {
+		return true
+	}
+	return apiErr.StatusCode == http.StatusBadRequest &&
+		strings.Contains(strings.ToLower(apiErr.Message+apiErr.Body), "existing project spec is different")
+}
+
+func (s *MCPServer) handleCreateProject(ctx context.Context, req *mcp.CallToolRequest, args CreateProjectArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	if err := args.validate(); err != nil {
+		return toolError(err)
+	}
+
+	var project Project
+	project.Metadata.Name = args.Name
+	project.Spec.Description = args.Description
+	project.Spec.SourceRepos = args.SourceRepos
+	project.Spec.Destinations = args.Destinations
+
+	var created Project
+	body := map[string]any{"project": project}
+	if err := s.argocdDo(ctx, "POST", "/api/v1/projects", nil, body, &created); err != nil {
+		if projectExists(err) {
+			err = fmt.Errorf("project %q already exists: %w", args.Name, err)
+		}
+		return toolError(err)
+	}
+
+	return toolJSON(created)
+}
+
+// isDenyPattern reports whether a project pattern is a "!" exclusion
+func isDenyPattern(pattern string) bool {
+	return strings.HasPrefix(pattern, "!")
+}
+
+// projectGlobMatch matches like globMatch, except that a "!" pattern matches
+// everything its remainder does not
+func projectGlobMatch(pattern, text string) bool {
+	if isDenyPattern(pattern) {
+		return !globMatch(pattern[1:], text)
+	}
+	return globMatch(pattern, text)
+}
+
+// normalizeRepoURL normalizes a Git URL the way ArgoCD does before matching
+func normalizeRepoURL(repo string) string {
+	repo = strings.ToLower(strings.TrimSpace(repo))
+	return strings.TrimSuffix(repo, ".git")
+}
+
+// sourcePermitted reports whether repoURL is allowed by a project's sourceRepos,
+// following ArgoCD's AppProject.IsSourcePermitted
+func sourcePermitted(sourceRepos []string, repoURL string) bool {
+	normalized := normalizeRepoURL(repoURL)
+	permitted := false
+	for _, pattern := range sourceRepos {
+		deny := isDenyPattern(pattern)
+		p := normalizeRepoURL(strings.TrimPrefix(pattern, "!"))
+		if deny {
+			p = "!" + p
+		}
+		if projectGlobMatch(p, normalized) {
+			permitted = true
+		} else if deny {
+			return false
+		}
+	}
+	return permitted
+}
+
+// deniedSources returns the repositories of an application's sources that a
+// project's sourceRepos don't permit
+func deniedSources(sourceRepos []string, app *ArgocdApplication) []string {
+	var denied []string
+	for _, source := range app.sources() {
+		if !sourcePermitted(sourceRepos, source.RepoURL) {
+			denied = append(denied, source.RepoURL)
+		}
+	}
+	return denied
+}
+
+// destinationPermitted reports whether a destination is allowed by a project's
+// destinations, following ArgoCD's AppProject.IsDestinationPermitted
+func destinationPermitted(destinations []ProjectDestination, server, name, namespace string) bool {
+	permitted := false
+	for _, item := range destinations {
+		nameMatched := name != "" && projectGlobMatch(item.Name, name)
+		serverMatched := server != "" && projectGlobMatch(item.Server, server)
+		namespaceMatched := projectGlobMatch(item.Namespace, namespace)
+
+		switch {
+		case (serverMatched || nameMatched) && namespaceMatched:
+			permitted = true
+		case ((!nameMatched && isDenyPattern(item.Name)) || (!serverMatched && isDenyPattern(item.Server))) && namespaceMatched:
+			return false
+		case !namespaceMatched && isDenyPattern(item.Namespace) && (serverMatched || nameMatched):
+			return false
+		}
+	}
+	return permitted
+}
+
+// ValidateProjectChangeArgs are the arguments for the validate_project_change tool
+type ValidateProjectChangeArgs struct {
+	Project      string               `json:"project" jsonschema:"the AppProject to check"`
+	SourceRepos  []string             `json:"source_repos,omitempty" jsonschema:"proposed sourceRepos; omit to keep the current ones"`
+	Destinations []ProjectDestination `json:"destinations,omitempty" jsonschema:"proposed destinations; omit to keep the current ones"`
+}
+
+// NonCompliantApplication is an app that a proposed project change would break
+type NonCompliantApplication struct {
+	Name        string   `json:"name"`
+	RepoURL     string   `json:"repo_url"`
+	Destination string   `json:"destination"`
+	Namespace   string   `json:"namespace"`
+	Reasons     []string `json:"reasons"`
+}
+
+// ProjectChangeReport is the result of the validate_project_change tool
+type ProjectChangeReport struct {
+	Project          string                    `json:"project"`
+	ApplicationsSeen int                       `json:"applications_checked"`
+	Compliant        int                       `json:"compliant"`
+	Affected         []NonCompliantApplication `json:"affected"`
+}
+
+func (s *MCPServer) handleValidateProjectChange(ctx context.Context, req *mcp.CallToolRequest, args ValidateProjectChangeArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	if args.Project == "" {
+		return toolError(fmt.Errorf("project is required"))
+	}
+	if args.SourceRepos == nil && args.Destinations == nil {
+		return toolError(fmt.Errorf("at least one of source_repos or destinations must be proposed"))
+	}
+
+	project, err := s.getProject(ctx, args.Project)
+	if err != nil {
+		return toolError(err)
+	}
+	sourceRepos, destinations := project.Spec.SourceRepos, project.Spec.Destinations
+	if args.SourceRepos != nil {
+		sourceRepos = args.SourceRepos
+	}
+	if args.Destinations != nil {
+		destinations = args.Destinations
+	}
+
+	apps, err := s.getArgocdApplications(ctx, ApplicationFilter{})
+	if err != nil {
+		return toolError(err)
+	}
+
+	// ArgoCD resolves name-only destinations to a server before matching
+	serversByName := map[string]string{}
+	namesByServer := map[string]string{}
+	if clusters, err := s.getClusters(ctx); err == nil {
+		for _, cluster := range clusters.Items {
+			serversByName[cluster.Name] = cluster.Server
+			namesByServer[cluster.Server] = cluster.Name
+		}
+	}
+
+	report := &ProjectChangeReport{
+		Project:  args.Project,
+		Affected: []NonCompliantApplication{},
+	}
+	for _, app := range apps.Items {
+		if app.Spec.Project != args.Project {
+			continue
+		}
+		report.ApplicationsSeen++
+
+		dest := app.Spec.Destination
+		server, name := dest.Server, dest.Name
+		if server == "" {
+			server = serversByName[name]
+		}
+		if name == "" {
+			name = namesByServer[server]
+		}
+
+		var reasons []string
+		for _, repo := range deniedSources(sourceRepos, &app) {
+			reasons = append(reasons, fmt.Sprintf("source repository %q would not be permitted", repo))
+		}
+		if !destinationPermitted(destinations, server, name, dest.Namespace) {
+			reasons = append(reasons, fmt.Sprintf("destination %s/%s would not be permitted", firstNonEmpty(server, name), dest.Namespace))
+		}
+
+		if len(reasons) == 0 {
+			report.Compliant++
+			continue
+		}
+		report.Affected = append(report.Affected, NonCompliantApplication{
+			Name:        app.Metadata.Name,
+			RepoURL:     app.sources()[0].RepoURL,
+			Destination: firstNonEmpty(server, name),
+			Namespace:   dest.Namespace,
+			Reasons:     reasons,
+		})
+	}
+
+	sort.Slice(report.Affected, func(i, j int) bool {
+		return report.Affected[i].Name < report.Affected[j].Name
+	})
+
+	return toolJSON(report)
+}
+
+// firstNonEmpty returns the first of values that isn't empty
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
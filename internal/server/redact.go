@@ -0,0 +1,136 @@
+package server
+
+import (
+	"log/slog"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// urlCredentialsPattern matches the userinfo part of URLs embedded in free text
+var urlCredentialsPattern = regexp.MustCompile(`(\w+://)[^/\s@]+@`)
+
+// redactURLCredentials strips any username/password from a repository URL
+func redactURLCredentials(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.User == nil {
+		return urlCredentialsPattern.ReplaceAllString(raw, "${1}")
+	}
+	u.User = nil
+	return u.String()
+}
+
+// redactTextCredentials strips URL credentials from arbitrary text such as error messages
+func redactTextCredentials(text string) string {
+	return urlCredentialsPattern.ReplaceAllString(text, "${1}")
+}
+
+// bearerPattern matches bearer credentials in free text, e.g. an echoed
+// Authorization header
+var bearerPattern = regexp.MustCompile(`(?i)(bearer\s+)[A-Za-z0-9\-._~+/]+=*`)
+
+// minMaskedTokenLength is the shortest token whose ends maskToken shows.
+// Showing eight characters of anything shorter would reveal most of it.
+const minMaskedTokenLength = 16
+
+// maskToken shortens a token to a recognizable but unusable form
+func maskToken(token string) string {
+	if len(token) < minMaskedTokenLength {
+		return "***"
+	}
+	return token[:4] + "..." + token[len(token)-4:]
+}
+
+// redactToken scrubs token, and anything that looks like a bearer credential,
+// from text that may end up in logs or error messages
+func redactToken(text, token string) string {
+	if token != "" {
+		text = strings.ReplaceAll(text, token, maskToken(token))
+	}
+	return bearerPattern.ReplaceAllString(text, "${1}[REDACTED]")
+}
+
+// sensitiveLogKeys are log attribute keys whose values are never written out
+var sensitiveLogKeys = map[string]bool{
+	"authorization": true,
+	"auth_token":    true,
+	"token":         true,
+	"password":      true,
+	"cookie":        true,
+}
+
+// redactLogAttr is a slog ReplaceAttr hook that drops the values of
+// credential attributes and scrubs bearer tokens from all other strings, so
+// no log level can leak them
+func redactLogAttr(groups []string, a slog.Attr) slog.Attr {
+	if sensitiveLogKeys[strings.ToLower(a.Key)] {
+		return slog.String(a.Key, "[REDACTED]")
+	}
+	switch a.Value.Kind() {
+	case slog.KindString:
+		a.Value = slog.StringValue(redactToken(a.Value.String(), ""))
+	case slog.KindAny:
+		if err, ok := a.Value.Any().(error); ok {
+			a.Value = slog.StringValue(redactToken(err.Error(), ""))
+		}
+	}
+	return a
+}
+
+// secretConfigFields are cluster config keys that hold credentials
+var secretConfigFields = map[string]bool{
+	"bearerToken": true,
+	"password":    true,
+	"certData":    true,
+	"keyData":     true,
+}
+
+// redactedPlaceholder marks a value that was removed from an export
+func redactedPlaceholder(path string) string {
+	return "<REDACTED:" + path + ">"
+}
+
+// redactSecretFields replaces credential values in a decoded cluster config
+// with placeholders, in place, and returns the paths it redacted. Exec
+// provider env values are redacted too since they commonly carry tokens.
+func redactSecretFields(obj map[string]any, path string) []string {
+	var redacted []string
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		childPath := key
+		if path != "" {
+			childPath = path + "." + key
+		}
+		switch val := obj[key].(type) {
+		case map[string]any:
+			if key == "env" {
+				redacted = append(redacted, redactAll(val, childPath)...)
+				continue
+			}
+			redacted = append(redacted, redactSecretFields(val, childPath)...)
+		case string:
+			if secretConfigFields[key] && val != "" {
+				obj[key] = redactedPlaceholder(childPath)
+				redacted = append(redacted, childPath)
+			}
+		}
+	}
+	return redacted
+}
+
+// redactAll replaces every value of obj with a placeholder
+func redactAll(obj map[string]any, path string) []string {
+	var redacted []string
+	for key := range obj {
+		obj[key] = redactedPlaceholder(path + "." + key)
+		redacted = append(redacted, path+"."+key)
+	}
+	sort.Strings(redacted)
+	return redacted
+}
@@ -0,0 +1,78 @@
+package server
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRedactToken(t *testing.T) {
+	const token = "eyJhbGciOiJIUzI1NiJ9.secret-payload.signature"
+
+	tests := []struct {
+		name  string
+		text  string
+		token string
+		want  string
+	}{
+		{"no token", "application not found", token, "application not found"},
+		{"raw token", "invalid token " + token, token, "invalid token eyJh...ture"},
+		{"authorization header", "Authorization: Bearer " + token, "", "Authorization: Bearer [REDACTED]"},
+		{"lowercase bearer", "got bearer abc.def-ghi=", "", "got bearer [REDACTED]"},
+		{"short token", "token x1 rejected", "x1", "token *** rejected"},
+		{"eight character token", "token abcd1234 rejected", "abcd1234", "token *** rejected"},
+		{"fifteen character token", "token abcdefg12345678 rejected", "abcdefg12345678", "token *** rejected"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := redactToken(tt.text, tt.token); got != tt.want {
+				t.Errorf("redactToken(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewAPIErrorRedactsToken(t *testing.T) {
+	const token = "super-secret-session-token"
+
+	req, _ := http.NewRequest("GET", "https://argocd.example.com/api/v1/applications", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp := &http.Response{
+		StatusCode: http.StatusUnauthorized,
+		Body:       io.NopCloser(strings.NewReader(`{"error":"token ` + token + ` is invalid","code":16}`)),
+		Request:    req,
+	}
+
+	err := newAPIError(resp)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("newAPIError() = %T, want *APIError", err)
+	}
+	if strings.Contains(apiErr.Body, token) || strings.Contains(apiErr.Error(), token) {
+		t.Errorf("newAPIError() leaked the token: %q", apiErr.Error())
+	}
+}
+
+func TestRedactLogAttr(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		Level:       slog.LevelDebug,
+		ReplaceAttr: redactLogAttr,
+	}))
+
+	logger.Debug("sending request",
+		"Authorization", "Bearer abc123",
+		"password", "hunter2",
+		"detail", "header was Bearer abc123",
+		"error", errors.New("echoed Bearer abc123"),
+	)
+
+	if out := buf.String(); strings.Contains(out, "abc123") || strings.Contains(out, "hunter2") {
+		t.Errorf("log output leaked a credential: %s", out)
+	}
+}
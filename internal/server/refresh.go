@@ -0,0 +1,189 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Refresh types accepted by the ArgoCD API
+const (
+	refreshNormal = "normal"
+	refreshHard   = "hard"
+)
+
+// RefreshRepoArgs are the arguments for the refresh_repo_applications tool
+type RefreshRepoArgs struct {
+	RepoURL string `json:"repo_url" jsonschema:"the Git repository URL whose applications should be refreshed"`
+}
+
+// RefreshApplicationArgs are the arguments for the refresh_application tool
+type RefreshApplicationArgs struct {
+	Name string `json:"name" jsonschema:"the application name"`
+	Hard bool   `json:"hard,omitempty" jsonschema:"also invalidate the repo-server manifest cache (slower)"`
+}
+
+// RefreshResult is the outcome of refreshing a single application
+type RefreshResult struct {
+	Refreshed    bool   `json:"refreshed"`
+	SyncStatus   string `json:"sync_status,omitempty"`
+	HealthStatus string `json:"health_status,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+// RefreshRepoReport is the result of the refresh_repo_applications tool
+type RefreshRepoReport struct {
+	RepoURL      string                   `json:"repo_url"`
+	Matched      int                      `json:"matched"`
+	Failed       int                      `json:"failed"`
+	Applications map[string]RefreshResult `json:"applications"`
+}
+
+// refreshApplication asks ArgoCD to re-read an application's source and
+// returns the refreshed application
+func (s *MCPServer) refreshApplication(ctx context.Context, name, refreshType string) (*ArgocdApplication, error) {
+	if refreshType != refreshNormal && refreshType != refreshHard {
+		return nil, fmt.Errorf("invalid refresh type %q: must be %s or %s", refreshType, refreshNormal, refreshHard)
+	}
+
+	var app ArgocdApplication
+	query := url.Values{"refresh": {refreshType}}
+	if err := s.argocdGet(ctx, applicationPath(name), query, &app); err != nil {
+		return nil, err
+	}
+	s.history.record(s.instance(ctx).name, []ArgocdApplication{app})
+	return &app, nil
+}
+
+func (s *MCPServer) handleRefreshApplication(ctx context.Context, req *mcp.CallToolRequest, args RefreshApplicationArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	if args.Name == "" {
+		return toolError(fmt.Errorf("name is required"))
+	}
+
+	refreshType := refreshNormal
+	if args.Hard {
+		refreshType = refreshHard
+	}
+
+	app, err := s.refreshApplication(ctx, args.Name, refreshType)
+	if err != nil {
+		return toolError(err)
+	}
+
+	return toolJSON(ApplicationStatus{
+		Name:         app.Metadata.Name,
+		SyncStatus:   app.Status.Sync.Status,
+		HealthStatus: app.Status.Health.Status,
+		Revision:     app.Status.Sync.Revision,
+	})
+}
+
+func (s *MCPServer) handleRefreshRepoApplications(ctx context.Context, req *mcp.CallToolRequest, args RefreshRepoArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	if args.RepoURL == "" {
+		return toolError(fmt.Errorf("repo_url is required"))
+	}
+
+	apps, err := s.getArgocdApplications(ctx, ApplicationFilter{})
+	if err != nil {
+		return toolError(err)
+	}
+
+	var names []string
+	for i := range apps.Items {
+		if usesRepo(&apps.Items[i], args.RepoURL) {
+			names = append(names, apps.Items[i].Metadata.Name)
+		}
+	}
+	sort.Strings(names)
+	results := make([]RefreshResult, len(names))
+	forEachConcurrent(ctx, len(names), func(ctx context.Context, i int) {
+		app, err := s.refreshApplication(ctx, names[i], refreshHard)
+		if err != nil {
+			results[i].Error = err.Error()
+			return
+		}
+		results[i] = RefreshResult{
+			Refreshed:    true,
+			SyncStatus:   app.Status.Sync.Status,
+			HealthStatus: app.Status.Health.Status,
+		}
+	})
+
+	report := &RefreshRepoReport{
+		RepoURL:      args.RepoURL,
+		Matched:      len(names),
+		Applications: make(map[string]RefreshResult, len(names)),
+	}
+	for i, name := range names {
+		// Apps never started because ctx was cancelled have no result yet
+		if !results[i].Refreshed && results[i].Error == "" {
+			results[i].Error = "not refreshed: " + context.Cause(ctx).Error()
+		}
+		if !results[i].Refreshed {
+			report.Failed++
+		}
+		report.Applications[name] = results[i]
+	}
+
+	return toolJSON(report)
+}
+
+// refreshAllEnv enables refresh_all_applications, which is off by default
+// because refreshing every app at once loads the repo-server heavily
+const refreshAllEnv = "ENABLE_REFRESH_ALL"
+
+// RefreshAllReport is the result of the refresh_all_applications tool
+type RefreshAllReport struct {
+	Total     int               `json:"total"`
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+	Skipped   int               `json:"skipped"`
+	Cancelled bool              `json:"cancelled,omitempty"`
+	Failures  map[string]string `json:"failures,omitempty"`
+}
+
+func (s *MCPServer) handleRefreshAllApplications(ctx context.Context, req *mcp.CallToolRequest, args struct{}) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	apps, err := s.getArgocdApplications(ctx, ApplicationFilter{})
+	if err != nil {
+		return toolError(err)
+	}
+
+	// started distinguishes apps that failed from ones never attempted
+	// because the request was cancelled
+	started := make([]bool, len(apps.Items))
+	errs := make([]error, len(apps.Items))
+	forEachConcurrent(ctx, len(apps.Items), func(ctx context.Context, i int) {
+		started[i] = true
+		_, errs[i] = s.refreshApplication(ctx, apps.Items[i].Metadata.Name, refreshHard)
+	})
+
+	report := &RefreshAllReport{
+		Total:     len(apps.Items),
+		Cancelled: ctx.Err() != nil,
+	}
+	for i, app := range apps.Items {
+		switch {
+		case !started[i]:
+			report.Skipped++
+		case errs[i] != nil:
+			report.Failed++
+			if report.Failures == nil {
+				report.Failures = make(map[string]string)
+			}
+			report.Failures[app.Metadata.Name] = errs[i].Error()
+		default:
+			report.Succeeded++
+		}
+	}
+
+	return toolJSON(report)
+}
@@ -0,0 +1,106 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// RenderManifestsArgs are the arguments for the render_manifests tool
+type RenderManifestsArgs struct {
+	RepoURL        string            `json:"repo_url" jsonschema:"the Git or Helm repository URL"`
+	Path           string            `json:"path,omitempty" jsonschema:"path within the repository (or chart name for Helm repositories)"`
+	TargetRevision string            `json:"target_revision,omitempty" jsonschema:"the revision to render (default HEAD)"`
+	Parameters     map[string]string `json:"parameters,omitempty" jsonschema:"Helm parameter overrides to apply"`
+}
+
+// AppDetails is the source analysis returned by the repository appdetails endpoint
+type AppDetails struct {
+	Type string `json:"type"`
+	Helm *struct {
+		Name       string   `json:"name,omitempty"`
+		ValueFiles []string `json:"valueFiles,omitempty"`
+		Parameters []struct {
+			Name  string `json:"name"`
+			Value string `json:"value"`
+		} `json:"parameters,omitempty"`
+	} `json:"helm,omitempty"`
+	Kustomize *struct {
+		Images []string `json:"images,omitempty"`
+	} `json:"kustomize,omitempty"`
+}
+
+// RenderManifestsResult is the result of the render_manifests tool
+type RenderManifestsResult struct {
+	Supported  bool        `json:"supported"`
+	RepoURL    string      `json:"repo_url"`
+	Path       string      `json:"path,omitempty"`
+	Revision   string      `json:"revision"`
+	SourceType string      `json:"source_type,omitempty"`
+	Details    *AppDetails `json:"details,omitempty"`
+	Message    string      `json:"message"`
+}
+
+// renderUnsupportedMessage explains why standalone rendering isn't available
+const renderUnsupportedMessage = "ArgoCD's REST API cannot render manifests for a source without an existing application: standalone rendering is only exposed by the repo-server over gRPC. The source was resolved and analysed instead. To preview manifests, use diff_revisions against an existing application that uses this source."
+
+func (s *MCPServer) handleRenderManifests(ctx context.Context, req *mcp.CallToolRequest, args RenderManifestsArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	if args.RepoURL == "" {
+		return toolError(fmt.Errorf("repo_url is required"))
+	}
+	revision := args.TargetRevision
+	if revision == "" {
+		revision = "HEAD"
+	}
+
+	source := map[string]any{
+		"repoURL":        args.RepoURL,
+		"path":           args.Path,
+		"targetRevision": revision,
+	}
+	if len(args.Parameters) > 0 {
+		names := make([]string, 0, len(args.Parameters))
+		for name := range args.Parameters {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		params := make([]map[string]string, 0, len(names))
+		for _, name := range names {
+			params = append(params, map[string]string{"name": name, "value": args.Parameters[name]})
+		}
+		source["helm"] = map[string]any{"parameters": params}
+	}
+
+	result := &RenderManifestsResult{
+		RepoURL:  redactURLCredentials(args.RepoURL),
+		Path:     args.Path,
+		Revision: revision,
+	}
+
+	var details AppDetails
+	path := "/api/v1/repositories/" + url.PathEscape(args.RepoURL) + "/appdetails"
+	err := s.argocdDo(ctx, "POST", path, nil, map[string]any{"source": source}, &details)
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusNotFound || apiErr.StatusCode == http.StatusNotImplemented) {
+			result.Message = "This ArgoCD instance does not expose source analysis or manifest rendering over its API."
+			return toolJSON(result)
+		}
+		// Error bodies can echo the repository URL, credentials included
+		return toolError(errors.New(redactTextCredentials(err.Error())))
+	}
+
+	result.SourceType = details.Type
+	result.Details = &details
+	result.Message = renderUnsupportedMessage
+
+	return toolJSON(result)
+}
@@ -0,0 +1,178 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// workloadKinds are the apps/v1 kinds whose replica counts are compared
+var workloadKinds = map[string]bool{
+	"Deployment":  true,
+	"StatefulSet": true,
+	"ReplicaSet":  true,
+}
+
+// WorkloadReplicas compares the desired and live replicas of a workload
+type WorkloadReplicas struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Desired   int    `json:"desired"`
+	// DesiredDefaulted is set when the manifest leaves replicas unset, as is
+	// usual under an HPA; Kubernetes then defaults to 1 or keeps the current scale
+	DesiredDefaulted bool `json:"desired_defaulted,omitempty"`
+	Live             int  `json:"live"`
+	Ready            int  `json:"ready"`
+	Mismatch         bool `json:"mismatch"`
+}
+
+// ReplicaReport is the result of the get_replica_status tool
+type ReplicaReport struct {
+	Application string             `json:"application"`
+	Mismatches  int                `json:"mismatches"`
+	Workloads   []WorkloadReplicas `json:"workloads"`
+	Note        string             `json:"note"`
+}
+
+// podReady reports whether all of a pod's containers are ready, using the
+// "Containers" info ArgoCD attaches to pod nodes (e.g. "2/2")
+func podReady(node *ResourceNode) bool {
+	for _, item := range node.Info {
+		if item.Name != "Containers" {
+			continue
+		}
+		ready, total, ok := strings.Cut(item.Value, "/")
+		return ok && ready == total
+	}
+	return false
+}
+
+// workloadPods counts the pods under each workload in the tree, following
+// parent references through intermediate ReplicaSets. Results are keyed by
+// manifestKey-style group/kind/namespace/name.
+func workloadPods(tree *ResourceTree) (live, ready map[string]int) {
+	byUID := make(map[string]*ResourceNode, len(tree.Nodes))
+	for i := range tree.Nodes {
+		if uid := tree.Nodes[i].UID; uid != "" {
+			byUID[uid] = &tree.Nodes[i]
+		}
+	}
+
+	live, ready = map[string]int{}, map[string]int{}
+	for i := range tree.Nodes {
+		pod := &tree.Nodes[i]
+		if pod.Kind != "Pod" {
+			continue
+		}
+
+		// Credit the pod to every workload ancestor, so a Deployment and
+		// its ReplicaSet both count it
+		seen := map[string]bool{}
+		queue := append([]ResourceRef(nil), pod.ParentRefs...)
+		for len(queue) > 0 {
+			ref := queue[0]
+			queue = queue[1:]
+			key := strings.Join([]string{ref.Group, ref.Kind, ref.Namespace, ref.Name}, "/")
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			if workloadKinds[ref.Kind] {
+				live[key]++
+				if podReady(pod) {
+					ready[key]++
+				}
+			}
+			if parent, ok := byUID[ref.UID]; ok {
+				queue = append(queue, parent.ParentRefs...)
+			}
+		}
+	}
+	return live, ready
+}
+
+func (s *MCPServer) handleGetReplicaStatus(ctx context.Context, req *mcp.CallToolRequest, args ApplicationNameArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	if args.Name == "" {
+		return toolError(fmt.Errorf("name is required"))
+	}
+
+	app, err := s.getApplication(ctx, args.Name)
+	if err != nil {
+		return toolError(err)
+	}
+	manifests, err := s.getApplicationManifests(ctx, args.Name, "")
+	if err != nil {
+		return toolError(err)
+	}
+	objects, err := parseManifests(manifests.Manifests)
+	if err != nil {
+		return toolError(err)
+	}
+	tree, err := s.getResourceTree(ctx, args.Name)
+	if err != nil {
+		return toolError(err)
+	}
+
+	live, ready := workloadPods(tree)
+
+	report := &ReplicaReport{
+		Application: args.Name,
+		Workloads:   []WorkloadReplicas{},
+		Note:        "Live counts are the pods found under each workload in ArgoCD's resource tree, including pods still starting or terminating during a rollout.",
+	}
+	for _, obj := range objects {
+		kind, _ := obj["kind"].(string)
+		apiVersion, _ := obj["apiVersion"].(string)
+		if !workloadKinds[kind] || !strings.HasPrefix(apiVersion, "apps/") {
+			continue
+		}
+		metadata, _ := obj["metadata"].(map[string]any)
+		name, _ := metadata["name"].(string)
+		namespace, _ := metadata["namespace"].(string)
+		if namespace == "" {
+			namespace = app.Spec.Destination.Namespace
+		}
+
+		w := WorkloadReplicas{Kind: kind, Namespace: namespace, Name: name, Desired: 1}
+		spec, _ := obj["spec"].(map[string]any)
+		// Manifests are decoded from JSON, so numbers arrive as float64
+		if replicas, ok := spec["replicas"].(float64); ok {
+			w.Desired = int(replicas)
+		} else {
+			w.DesiredDefaulted = true
+		}
+
+		key := strings.Join([]string{"apps", kind, namespace, name}, "/")
+		w.Live, w.Ready = live[key], ready[key]
+		// Without replicas in the manifest the live scale is whatever the
+		// HPA or last apply chose, so only a missing workload is a mismatch
+		if w.DesiredDefaulted {
+			w.Mismatch = w.Live == 0
+		} else {
+			w.Mismatch = w.Live != w.Desired || w.Ready != w.Desired
+		}
+		if w.Mismatch {
+			report.Mismatches++
+		}
+		report.Workloads = append(report.Workloads, w)
+	}
+
+	sort.Slice(report.Workloads, func(i, j int) bool {
+		a, b := report.Workloads[i], report.Workloads[j]
+		if a.Mismatch != b.Mismatch {
+			return a.Mismatch
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Namespace+"/"+a.Name < b.Namespace+"/"+b.Name
+	})
+
+	return toolJSON(report)
+}
@@ -0,0 +1,58 @@
+package server
+
+import (
+	"context"
+	"sort"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Repository is a source repository registered in ArgoCD. Credentials are
+// deliberately not decoded.
+type Repository struct {
+	Repo            string `json:"repo"`
+	Type            string `json:"type"`
+	Name            string `json:"name,omitempty"`
+	Project         string `json:"project,omitempty"`
+	ConnectionState struct {
+		Status      string `json:"status"`
+		Message     string `json:"message,omitempty"`
+		AttemptedAt string `json:"attemptedAt,omitempty"`
+	} `json:"connectionState"`
+}
+
+// RepositoryList represents a list of ArgoCD repositories
+type RepositoryList struct {
+	Items []Repository `json:"items"`
+}
+
+func (s *MCPServer) handleListRepositories(ctx context.Context, req *mcp.CallToolRequest, args struct{}) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	var repos RepositoryList
+	if err := s.argocdGet(ctx, "/api/v1/repositories", nil, &repos); err != nil {
+		return toolError(err)
+	}
+	if repos.Items == nil {
+		repos.Items = []Repository{}
+	}
+
+	for i := range repos.Items {
+		// ArgoCD leaves type empty for Git repositories
+		if repos.Items[i].Type == "" {
+			repos.Items[i].Type = "git"
+		}
+	}
+
+	// Failed connections first, since those are what callers look for
+	sort.SliceStable(repos.Items, func(i, j int) bool {
+		iFailed := repos.Items[i].ConnectionState.Status == "Failed"
+		jFailed := repos.Items[j].ConnectionState.Status == "Failed"
+		if iFailed != jFailed {
+			return iFailed
+		}
+		return repos.Items[i].Repo < repos.Items[j].Repo
+	})
+
+	return toolJSON(repos)
+}
@@ -0,0 +1,206 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ResourceNode is a single live resource in an application's resource tree
+type ResourceNode struct {
+	Group     string `json:"group,omitempty"`
+	Version   string `json:"version,omitempty"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	UID       string `json:"uid,omitempty"`
+	Health    *struct {
+		Status  string `json:"status,omitempty"`
+		Message string `json:"message,omitempty"`
+	} `json:"health,omitempty"`
+	ParentRefs []ResourceRef `json:"parentRefs,omitempty"`
+	Info       []InfoItem    `json:"info,omitempty"`
+}
+
+// ResourceRef identifies a resource in the resource tree
+type ResourceRef struct {
+	Group     string `json:"group,omitempty"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	UID       string `json:"uid,omitempty"`
+}
+
+// InfoItem is a name/value detail ArgoCD attaches to a tree node
+type InfoItem struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// ResourceStatus is a managed resource as listed in an application's status
+type ResourceStatus struct {
+	Group     string `json:"group,omitempty"`
+	Version   string `json:"version,omitempty"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Status    string `json:"status,omitempty"`
+	Health    *struct {
+		Status  string `json:"status,omitempty"`
+		Message string `json:"message,omitempty"`
+	} `json:"health,omitempty"`
+}
+
+// ResourceTree is the live resource tree of an application
+type ResourceTree struct {
+	Nodes []ResourceNode `json:"nodes"`
+}
+
+// getResourceTree fetches the live resource tree of an application
+func (s *MCPServer) getResourceTree(ctx context.Context, name string) (*ResourceTree, error) {
+	var tree ResourceTree
+	if err := s.argocdGet(ctx, applicationPath(name)+"/resource-tree", nil, &tree); err != nil {
+		return nil, err
+	}
+	return &tree, nil
+}
+
+// ResourceCountArgs are the arguments for the get_resource_count tool
+type ResourceCountArgs struct {
+	Name        string `json:"name,omitempty" jsonschema:"application name; omit to count every application"`
+	IncludeLive bool   `json:"include_live,omitempty" jsonschema:"also count all live resources in the resource tree, including generated ones like Pods and ReplicaSets"`
+}
+
+// ResourceCount is the number of resources an application manages
+type ResourceCount struct {
+	Name             string `json:"name"`
+	ManagedResources int    `json:"managed_resources"`
+	LiveResources    *int   `json:"live_resources,omitempty"`
+	Error            string `json:"error,omitempty"`
+}
+
+func (s *MCPServer) handleGetResourceCount(ctx context.Context, req *mcp.CallToolRequest, args ResourceCountArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	var apps []ArgocdApplication
+	if args.Name != "" {
+		app, err := s.getApplication(ctx, args.Name)
+		if err != nil {
+			return toolError(err)
+		}
+		apps = []ArgocdApplication{*app}
+	} else {
+		list, err := s.getArgocdApplications(ctx, ApplicationFilter{})
+		if err != nil {
+			return toolError(err)
+		}
+		apps = list.Items
+	}
+
+	counts := make([]ResourceCount, len(apps))
+	for i, app := range apps {
+		counts[i] = ResourceCount{
+			Name:             app.Metadata.Name,
+			ManagedResources: len(app.Status.Resources),
+		}
+	}
+
+	if args.IncludeLive {
+		forEachConcurrent(ctx, len(counts), func(ctx context.Context, i int) {
+			tree, err := s.getResourceTree(ctx, counts[i].Name)
+			if err != nil {
+				counts[i].Error = fmt.Sprintf("failed to get resource tree: %v", err)
+				return
+			}
+			live := len(tree.Nodes)
+			counts[i].LiveResources = &live
+		})
+	}
+
+	sort.SliceStable(counts, func(i, j int) bool {
+		if args.IncludeLive && counts[i].LiveResources != nil && counts[j].LiveResources != nil &&
+			*counts[i].LiveResources != *counts[j].LiveResources {
+			return *counts[i].LiveResources > *counts[j].LiveResources
+		}
+		if counts[i].ManagedResources != counts[j].ManagedResources {
+			return counts[i].ManagedResources > counts[j].ManagedResources
+		}
+		return counts[i].Name < counts[j].Name
+	})
+
+	return toolJSON(counts)
+}
+
+// ResourceTreeArgs are the arguments for the get_application_resource_tree tool
+type ResourceTreeArgs struct {
+	Name string `json:"name" jsonschema:"the application name"`
+	Kind string `json:"kind,omitempty" jsonschema:"only return nodes of this kind, e.g. Pod"`
+}
+
+// TreeNode is a compact view of a resource tree node
+type TreeNode struct {
+	Kind          string `json:"kind"`
+	Namespace     string `json:"namespace,omitempty"`
+	Name          string `json:"name"`
+	Health        string `json:"health,omitempty"`
+	HealthMessage string `json:"health_message,omitempty"`
+	Parent        string `json:"parent,omitempty"`
+}
+
+// ApplicationResourceTree is the result of the get_application_resource_tree tool
+type ApplicationResourceTree struct {
+	Name  string     `json:"name"`
+	Nodes []TreeNode `json:"nodes"`
+}
+
+func (s *MCPServer) handleGetApplicationResourceTree(ctx context.Context, req *mcp.CallToolRequest, args ResourceTreeArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	if args.Name == "" {
+		return toolError(fmt.Errorf("name is required"))
+	}
+
+	tree, err := s.getResourceTree(ctx, args.Name)
+	if err != nil {
+		return toolError(err)
+	}
+
+	result := &ApplicationResourceTree{
+		Name:  args.Name,
+		Nodes: []TreeNode{},
+	}
+	for _, node := range tree.Nodes {
+		if args.Kind != "" && !strings.EqualFold(node.Kind, args.Kind) {
+			continue
+		}
+		n := TreeNode{
+			Kind:      node.Kind,
+			Namespace: node.Namespace,
+			Name:      node.Name,
+		}
+		if node.Health != nil {
+			n.Health = node.Health.Status
+			n.HealthMessage = node.Health.Message
+		}
+		if len(node.ParentRefs) > 0 {
+			n.Parent = node.ParentRefs[0].Kind + "/" + node.ParentRefs[0].Name
+		}
+		result.Nodes = append(result.Nodes, n)
+	}
+
+	sort.Slice(result.Nodes, func(i, j int) bool {
+		a, b := result.Nodes[i], result.Nodes[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+
+	return toolJSON(result)
+}
@@ -0,0 +1,113 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// refreshAnnotation is set on an application while a requested refresh is
+// pending and removed by the application controller once it's done
+const refreshAnnotation = "argocd.argoproj.io/refresh"
+
+// resyncStepRefresh is the failed_step of resync_application when the
+// refresh fails; syncAndWait reports the sync and wait steps
+const resyncStepRefresh = "refresh"
+
+// ResyncApplicationArgs are the arguments for the resync_application tool
+type ResyncApplicationArgs struct {
+	Name           string `json:"name" jsonschema:"the application name"`
+	Prune          bool   `json:"prune,omitempty" jsonschema:"delete resources that are no longer defined in Git"`
+	TimeoutSeconds int    `json:"timeout_seconds,omitempty" jsonschema:"how long to wait for the refresh and the sync together (default 300, at most 1800)"`
+}
+
+// ResyncReport is the result of the resync_application tool
+type ResyncReport struct {
+	Application string `json:"application"`
+	// Outcome is succeeded or failed once the sync finished, timeout or
+	// cancelled when waiting stopped early, and error when a step failed
+	Outcome string `json:"outcome"`
+	// FailedStep is refresh, sync or wait when Outcome is error
+	FailedStep string        `json:"failed_step,omitempty"`
+	Error      string        `json:"error,omitempty"`
+	Message    string        `json:"message,omitempty"`
+	Refresh    RefreshResult `json:"refresh"`
+	Sync       *SyncReport   `json:"sync,omitempty"`
+}
+
+// waitForRefresh polls an application until the controller has processed
+// the pending refresh
+func (s *MCPServer) waitForRefresh(ctx context.Context, name string) (*ArgocdApplication, error) {
+	var last *ArgocdApplication
+	err := poll(ctx, s.pollInterval("resync_application"), func(ctx context.Context) (bool, error) {
+		app, err := s.getApplication(ctx, name)
+		if err != nil {
+			return false, err
+		}
+		last = app
+		_, pending := app.Metadata.Annotations[refreshAnnotation]
+		return !pending, nil
+	})
+	return last, err
+}
+
+func (s *MCPServer) handleResyncApplication(ctx context.Context, req *mcp.CallToolRequest, args ResyncApplicationArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	if args.Name == "" {
+		return toolError(fmt.Errorf("name is required"))
+	}
+	timeout := toolTimeout(args.TimeoutSeconds, defaultSyncTimeout)
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	report := &ResyncReport{Application: args.Name}
+	fail := func(step string, err error) (*mcp.CallToolResult, any, error) {
+		report.Outcome = "error"
+		report.FailedStep = step
+		report.Error = err.Error()
+		res, _, marshalErr := toolJSON(report)
+		if marshalErr != nil {
+			return nil, nil, marshalErr
+		}
+		res.IsError = true
+		return res, nil, nil
+	}
+
+	// ArgoCD's API server waits for the refresh itself, but only for a while,
+	// so make sure it's done before syncing against stale manifests
+	_, err := s.refreshApplication(waitCtx, args.Name, refreshHard)
+	var app *ArgocdApplication
+	if err == nil {
+		app, err = s.waitForRefresh(waitCtx, args.Name)
+	}
+	if err != nil {
+		if waitCtx.Err() == nil {
+			return fail(resyncStepRefresh, err)
+		}
+		report.Outcome, report.Message = stoppedWaiting(ctx, timeout, "refresh")
+		return toolJSON(report)
+	}
+	report.Refresh = RefreshResult{
+		Refreshed:    true,
+		SyncStatus:   app.Status.Sync.Status,
+		HealthStatus: app.Status.Health.Status,
+	}
+
+	// Stop here rather than start a sync nobody is waiting for
+	if waitCtx.Err() != nil {
+		report.Outcome, report.Message = stoppedWaiting(ctx, timeout, "refresh")
+		return toolJSON(report)
+	}
+
+	sync, step, err := s.syncAndWait(ctx, waitCtx, timeout, app, SyncRequest{Name: args.Name, Prune: args.Prune}, "resync_application")
+	if err != nil {
+		return fail(step, err)
+	}
+	report.Outcome = sync.Outcome
+	report.Sync = sync
+
+	return toolJSON(report)
+}
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// defaultMaxRetries is used when ARGOCD_MAX_RETRIES is unset or invalid
+	defaultMaxRetries = 3
+
+	// retryBaseDelay is the first backoff delay; it doubles on each attempt
+	retryBaseDelay = 500 * time.Millisecond
+
+	// retryMaxDelay caps the backoff delay
+	retryMaxDelay = 8 * time.Second
+)
+
+// loadMaxRetries reads ARGOCD_MAX_RETRIES. Zero disables retries.
+func loadMaxRetries() int {
+	value := getEnvWithDefault("ARGOCD_MAX_RETRIES", strconv.Itoa(defaultMaxRetries))
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		slog.Warn("Invalid ARGOCD_MAX_RETRIES, using default", "value", value, "default", defaultMaxRetries)
+		return defaultMaxRetries
+	}
+	return n
+}
+
+// retryable reports whether a response or error is transient, e.g. ArgoCD
+// restarting behind a load balancer
+func retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode >= http.StatusInternalServerError
+}
+
+// backoff returns the delay before retry attempt n (starting at 0)
+func backoff(n int) time.Duration {
+	d := retryBaseDelay << n
+	if d <= 0 || d > retryMaxDelay {
+		return retryMaxDelay
+	}
+	return d
+}
+
+// send performs req, retrying transient failures with exponential backoff.
+// Only idempotent requests are retried, since resending a sync or delete
+// after a 5xx could repeat an operation ArgoCD already started.
+func (s *MCPServer) send(req *http.Request) (*http.Response, error) {
+	client := s.instance(req.Context()).client(req.Context())
+	resp, err := client.Do(req)
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return resp, err
+	}
+
+	for attempt := 0; attempt < s.maxRetries && retryable(resp, err); attempt++ {
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		delay := backoff(attempt)
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, context.Cause(req.Context())
+		case <-timer.C:
+		}
+
+		slog.Warn("Retrying ArgoCD request after transient failure", "method", req.Method, "path", req.URL.Path, "attempt", attempt+1, "max_retries", s.maxRetries)
+		resp, err = client.Do(req)
+	}
+	return resp, err
+}
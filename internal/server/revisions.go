@@ -0,0 +1,133 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// RevisionMetadata describes a Git commit as reported by ArgoCD
+type RevisionMetadata struct {
+	Author  string   `json:"author,omitempty"`
+	Date    string   `json:"date,omitempty"`
+	Tags    []string `json:"tags,omitempty"`
+	Message string   `json:"message,omitempty"`
+}
+
+// RevisionInfo is a revision together with its commit details
+type RevisionInfo struct {
+	Revision string `json:"revision"`
+	Message  string `json:"message,omitempty"`
+	Author   string `json:"author,omitempty"`
+	Date     string `json:"date,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// RevisionStatus is the result of the get_revision_status tool
+type RevisionStatus struct {
+	Application    string        `json:"application"`
+	TargetRevision string        `json:"target_revision"`
+	Current        *RevisionInfo `json:"current,omitempty"`
+	Latest         *RevisionInfo `json:"latest,omitempty"`
+	UpToDate       bool          `json:"up_to_date"`
+	Summary        string        `json:"summary"`
+}
+
+// getRevisionMetadata fetches the commit details of a revision of an app's source
+func (s *MCPServer) getRevisionMetadata(ctx context.Context, name, revision string) (*RevisionMetadata, error) {
+	var metadata RevisionMetadata
+	path := applicationPath(name) + "/revisions/" + url.PathEscape(revision) + "/metadata"
+	if err := s.argocdGet(ctx, path, nil, &metadata); err != nil {
+		return nil, err
+	}
+	return &metadata, nil
+}
+
+// deployedRevision returns the revision an app was last synced to
+func deployedRevision(app *ArgocdApplication) string {
+	if n := len(app.Status.History); n > 0 {
+		return app.Status.History[n-1].Revision
+	}
+	if result := app.Status.OperationState.SyncResult; result != nil {
+		return result.Revision
+	}
+	return ""
+}
+
+// describeRevision renders a revision as a short SHA and the first line of its message
+func describeRevision(info *RevisionInfo) string {
+	short := info.Revision
+	if len(short) > 7 {
+		short = short[:7]
+	}
+	subject, _, _ := strings.Cut(info.Message, "\n")
+	if subject == "" {
+		return short
+	}
+	return fmt.Sprintf("%s (%q)", short, subject)
+}
+
+// revisionInfo looks up the commit details of a revision, recording a
+// failure (e.g. for Helm chart versions) rather than failing the tool
+func (s *MCPServer) revisionInfo(ctx context.Context, name, revision string) *RevisionInfo {
+	info := &RevisionInfo{Revision: revision}
+	metadata, err := s.getRevisionMetadata(ctx, name, revision)
+	if err != nil {
+		info.Error = err.Error()
+		return info
+	}
+	info.Message = strings.TrimSpace(metadata.Message)
+	info.Author = metadata.Author
+	info.Date = metadata.Date
+	return info
+}
+
+func (s *MCPServer) handleGetRevisionStatus(ctx context.Context, req *mcp.CallToolRequest, args ApplicationNameArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	if args.Name == "" {
+		return toolError(fmt.Errorf("name is required"))
+	}
+
+	app, err := s.getApplication(ctx, args.Name)
+	if err != nil {
+		return toolError(err)
+	}
+
+	status := &RevisionStatus{
+		Application:    app.Metadata.Name,
+		TargetRevision: firstNonEmpty(app.Spec.Source.TargetRevision, "HEAD"),
+	}
+
+	// status.sync.revision is what the target revision currently resolves to
+	current, latest := deployedRevision(app), app.Status.Sync.Revision
+	if current != "" {
+		status.Current = s.revisionInfo(ctx, args.Name, current)
+	}
+	switch {
+	case latest == "":
+	case latest == current:
+		status.Latest = status.Current
+	default:
+		status.Latest = s.revisionInfo(ctx, args.Name, latest)
+	}
+	status.UpToDate = current != "" && current == latest
+
+	switch {
+	case status.Current == nil && status.Latest == nil:
+		status.Summary = "ArgoCD hasn't resolved or deployed a revision for this application yet."
+	case status.Current == nil:
+		status.Summary = fmt.Sprintf("Nothing has been deployed yet; latest on %s is %s.", status.TargetRevision, describeRevision(status.Latest))
+	case status.UpToDate:
+		status.Summary = fmt.Sprintf("Up to date: deployed at %s, the latest on %s.", describeRevision(status.Current), status.TargetRevision)
+	case status.Latest == nil:
+		status.Summary = fmt.Sprintf("Deployed at %s; ArgoCD hasn't resolved the latest revision of %s.", describeRevision(status.Current), status.TargetRevision)
+	default:
+		status.Summary = fmt.Sprintf("You're on %s, latest on %s is %s.", describeRevision(status.Current), status.TargetRevision, describeRevision(status.Latest))
+	}
+
+	return toolJSON(status)
+}
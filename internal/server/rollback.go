@@ -0,0 +1,91 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// RollbackRequest is the body of an ArgoCD application rollback request
+type RollbackRequest struct {
+	Name   string `json:"name"`
+	ID     int64  `json:"id"`
+	Prune  bool   `json:"prune,omitempty"`
+	DryRun bool   `json:"dryRun,omitempty"`
+}
+
+// RollbackApplicationArgs are the arguments for the rollback_application tool
+type RollbackApplicationArgs struct {
+	Name   string `json:"name" jsonschema:"the application name"`
+	ID     int64  `json:"id" jsonschema:"the deployment history ID to roll back to, as listed in status.history"`
+	Prune  bool   `json:"prune,omitempty" jsonschema:"delete resources that are not part of the target revision"`
+	DryRun bool   `json:"dry_run,omitempty" jsonschema:"preview the rollback without applying it"`
+}
+
+// ApplicationHistoryArgs are the arguments for the get_application_history tool
+type ApplicationHistoryArgs struct {
+	Name string `json:"name" jsonschema:"the application name"`
+}
+
+// ApplicationHistory is the result of the get_application_history tool
+type ApplicationHistory struct {
+	Name    string            `json:"name"`
+	History []RevisionHistory `json:"history"`
+}
+
+func (s *MCPServer) handleGetApplicationHistory(ctx context.Context, req *mcp.CallToolRequest, args ApplicationHistoryArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	if args.Name == "" {
+		return toolError(fmt.Errorf("name is required"))
+	}
+
+	app, err := s.getApplication(ctx, args.Name)
+	if err != nil {
+		return toolError(err)
+	}
+
+	history := append([]RevisionHistory{}, app.Status.History...)
+	// History IDs increase with each deploy, so they order entries even when
+	// timestamps tie
+	sort.Slice(history, func(i, j int) bool {
+		if history[i].DeployedAt != history[j].DeployedAt {
+			return history[i].DeployedAt > history[j].DeployedAt
+		}
+		return history[i].ID > history[j].ID
+	})
+
+	return toolJSON(ApplicationHistory{
+		Name:    app.Metadata.Name,
+		History: history,
+	})
+}
+
+func (s *MCPServer) handleRollbackApplication(ctx context.Context, req *mcp.CallToolRequest, args RollbackApplicationArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	if args.Name == "" {
+		return toolError(fmt.Errorf("name is required"))
+	}
+	if args.ID <= 0 {
+		return toolError(fmt.Errorf("id must be a positive deployment history ID, got %d", args.ID))
+	}
+
+	var app ArgocdApplication
+	rollback := RollbackRequest{
+		Name:   args.Name,
+		ID:     args.ID,
+		Prune:  args.Prune,
+		DryRun: args.DryRun,
+	}
+	if err := s.argocdDo(ctx, "POST", applicationPath(args.Name)+"/rollback", nil, rollback, &app); err != nil {
+		return toolError(err)
+	}
+	if !args.DryRun {
+		s.history.recordOperation(s.instance(ctx).name, args.Name)
+	}
+
+	return toolJSON(app.Status.OperationState)
+}
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// connectTestClient starts an MCPServer against a placeholder ArgoCD address
+// and connects an in-memory client to it
+func connectTestClient(t *testing.T) *mcp.ClientSession {
+	t.Helper()
+	t.Setenv("ARGOCD_SERVER", "https://argocd.invalid")
+	t.Setenv("ARGOCD_SERVERS", "")
+
+	s, err := NewMCPServer()
+	if err != nil {
+		t.Fatalf("NewMCPServer() error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	go s.server.Run(ctx, serverTransport)
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("failed to connect client: %v", err)
+	}
+	t.Cleanup(func() { session.Close() })
+	return session
+}
+
+func TestToolInputSchemas(t *testing.T) {
+	session := connectTestClient(t)
+
+	tools, err := session.ListTools(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("ListTools() error: %v", err)
+	}
+	for _, tool := range tools.Tools {
+		schema := tool.InputSchema
+		if schema == nil || schema.Type != "object" {
+			t.Errorf("tool %s: input schema is not an object schema", tool.Name)
+			continue
+		}
+		if schema.Properties[instanceArg] == nil {
+			t.Errorf("tool %s: input schema lacks the %s property", tool.Name, instanceArg)
+		}
+	}
+}
+
+func TestToolInputValidation(t *testing.T) {
+	session := connectTestClient(t)
+
+	tests := []struct {
+		name string
+		args map[string]any
+	}{
+		{"missing required name", map[string]any{}},
+		{"empty name", map[string]any{"name": ""}},
+		{"wrong type", map[string]any{"name": 42}},
+		{"unknown argument", map[string]any{"name": "guestbook", "nmae": "typo"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+				Name:      "get_application",
+				Arguments: tt.args,
+			})
+			if err == nil {
+				t.Errorf("get_application(%v) was accepted, want a schema validation error", tt.args)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// defaultSearchLimit is the number of matches returned when no limit is given
+const defaultSearchLimit = 10
+
+// Field weights used to rank search matches; a hit in the name counts the most
+var searchFieldWeights = []struct {
+	field  string
+	weight int
+	value  func(app *ArgocdApplication) string
+}{
+	{"name", 10, func(app *ArgocdApplication) string { return app.Metadata.Name }},
+	{"path", 5, sourcePaths},
+	{"project", 4, func(app *ArgocdApplication) string { return app.Spec.Project }},
+	{"destination_namespace", 3, func(app *ArgocdApplication) string { return app.Spec.Destination.Namespace }},
+	{"repo_url", 2, sourceRepoURLs},
+}
+
+// sourcePaths and sourceRepoURLs join a field of every source of app, one
+// per line. Search terms never contain whitespace, so a term can't match
+// across two sources.
+func sourcePaths(app *ArgocdApplication) string {
+	var paths []string
+	for _, source := range app.sources() {
+		paths = append(paths, source.Path)
+	}
+	return strings.Join(paths, "\n")
+}
+
+func sourceRepoURLs(app *ArgocdApplication) string {
+	var repos []string
+	for _, source := range app.sources() {
+		repos = append(repos, source.RepoURL)
+	}
+	return strings.Join(repos, "\n")
+}
+
+// matchingSource returns the first source of app whose repo URL or path
+// contains a search term, or its first source when none does
+func matchingSource(app *ArgocdApplication, terms []string) ApplicationSource {
+	sources := app.sources()
+	for _, source := range sources {
+		text := strings.ToLower(source.RepoURL + "\n" + source.Path)
+		for _, term := range terms {
+			if strings.Contains(text, term) {
+				return source
+			}
+		}
+	}
+	return sources[0]
+}
+
+// SearchApplicationsArgs are the arguments for the search_applications tool
+type SearchApplicationsArgs struct {
+	Query string `json:"query" jsonschema:"free text to match against app name, project, repo URL, path and destination namespace"`
+	Limit int    `json:"limit,omitempty" jsonschema:"maximum number of matches to return (default 10)"`
+}
+
+// ApplicationSearchResult is a single ranked search match. For multi-source
+// applications, RepoURL and Path are those of the source that matched.
+type ApplicationSearchResult struct {
+	Name                 string   `json:"name"`
+	Project              string   `json:"project"`
+	RepoURL              string   `json:"repo_url"`
+	Path                 string   `json:"path"`
+	DestinationNamespace string   `json:"destination_namespace"`
+	Score                int      `json:"score"`
+	MatchedFields        []string `json:"matched_fields"`
+}
+
+// scoreApplication rates how well app matches the query terms. Each term is
+// matched case-insensitively as a substring of each field, and an exact name
+// match gets a bonus.
+func scoreApplication(app *ArgocdApplication, query string, terms []string) (int, []string) {
+	score := 0
+	var matched []string
+
+	for _, f := range searchFieldWeights {
+		value := strings.ToLower(f.value(app))
+		if value == "" {
+			continue
+		}
+		hit := false
+		for _, term := range terms {
+			if strings.Contains(value, term) {
+				score += f.weight
+				hit = true
+			}
+		}
+		if hit {
+			matched = append(matched, f.field)
+		}
+	}
+
+	if strings.EqualFold(app.Metadata.Name, query) {
+		score += 50
+	}
+
+	return score, matched
+}
+
+func (s *MCPServer) handleSearchApplications(ctx context.Context, req *mcp.CallToolRequest, args SearchApplicationsArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	query := strings.TrimSpace(args.Query)
+	if query == "" {
+		return toolError(fmt.Errorf("query is required"))
+	}
+	limit := args.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+
+	apps, err := s.getArgocdApplications(ctx, ApplicationFilter{})
+	if err != nil {
+		return toolError(err)
+	}
+
+	terms := strings.Fields(strings.ToLower(query))
+	results := []ApplicationSearchResult{}
+	for i := range apps.Items {
+		app := &apps.Items[i]
+		score, matched := scoreApplication(app, query, terms)
+		if score == 0 {
+			continue
+		}
+		source := matchingSource(app, terms)
+		results = append(results, ApplicationSearchResult{
+			Name:                 app.Metadata.Name,
+			Project:              app.Spec.Project,
+			RepoURL:              source.RepoURL,
+			Path:                 source.Path,
+			DestinationNamespace: app.Spec.Destination.Namespace,
+			Score:                score,
+			MatchedFields:        matched,
+		})
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Name < results[j].Name
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	return toolJSON(results)
+}
@@ -2,13 +2,15 @@ package server
 
 import (
 	"context"
-	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
-	"log"
-	"net/http"
+	"log/slog"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -17,11 +19,48 @@ import (
 
 // MCPServer represents our ArgoCD MCP server instance
 type MCPServer struct {
-	server     *mcp.Server
-	config     *ServerConfig
-	status     *ServerStatus
-	argocdCfg  *ArgocdConfig
-	httpClient *http.Client
+	server   *mcp.Server
+	config   *ServerConfig
+	status   *ServerStatus
+	statusMu sync.Mutex // guards status, updated by concurrent handlers
+	history  *statusHistory
+	pollCfg  *PollConfig
+	appCache *appListCache
+
+	// instances are the configured ArgoCD servers by name; primary serves
+	// tool calls that don't select one
+	instances map[string]*argocdInstance
+	primary   *argocdInstance
+
+	// requestTimeout is the deadline applied to each incoming MCP request
+	requestTimeout time.Duration
+
+	// callTimeout bounds each ArgoCD API call
+	callTimeout time.Duration
+
+	// maxRetries is how many times transient ArgoCD failures are retried
+	maxRetries int
+
+	// maxResponseBytes caps the size of ArgoCD response bodies read into memory
+	maxResponseBytes int64
+
+	// compactJSON makes JSON results compact instead of indented unless a
+	// tool call says otherwise
+	compactJSON bool
+
+	// defaultProject is the project new applications are created in when
+	// none is given; defaultAppNamespace is get_application's namespace
+	// when none is given
+	defaultProject      string
+	defaultAppNamespace string
+
+	// core is set in ArgoCD core mode, where applications are read from
+	// Kubernetes instead of the ArgoCD API server
+	core *kubeClient
+
+	// anonymous is set when running without credentials against an
+	// instance that allows anonymous read access
+	anonymous bool
 }
 
 // ServerConfig holds server configuration
@@ -33,36 +72,132 @@ type ServerConfig struct {
 
 // ArgocdConfig holds ArgoCD connection configuration
 type ArgocdConfig struct {
-	ServerURL   string `json:"server_url"`
-	AuthToken   string `json:"auth_token,omitempty"`
-	Insecure    bool   `json:"insecure"`
+	ServerURL     string `json:"server_url"`
+	AuthToken     string `json:"auth_token,omitempty"`
+	Username      string `json:"username,omitempty"`
+	Password      string `json:"-"`
+	Insecure      bool   `json:"insecure"`
+	TLSServerName string `json:"tls_server_name,omitempty"`
+	// CACert is a PEM bundle of CAs trusted for the ArgoCD certificate
+	CACert string `json:"ca_cert,omitempty"`
+	// ClientCert and ClientKey are PEM file paths for mTLS
+	ClientCert string `json:"client_cert,omitempty"`
+	ClientKey  string `json:"client_key,omitempty"`
+	// AuthTokenFile is read for the token instead of AuthToken, e.g. a
+	// mounted Kubernetes secret. It is re-read when ArgoCD rejects the token.
+	AuthTokenFile string `json:"auth_token_file,omitempty"`
+}
+
+// ApplicationSource is where an application's manifests come from, with any
+// Helm or Kustomize overrides
+type ApplicationSource struct {
+	RepoURL        string           `json:"repoURL"`
+	Path           string           `json:"path"`
+	TargetRevision string           `json:"targetRevision"`
+	Chart          string           `json:"chart,omitempty"`
+	Helm           *HelmSource      `json:"helm,omitempty"`
+	Kustomize      *KustomizeSource `json:"kustomize,omitempty"`
+}
+
+// HelmSource holds the Helm overrides of an application source
+type HelmSource struct {
+	ReleaseName  string          `json:"releaseName,omitempty"`
+	ValueFiles   []string        `json:"valueFiles,omitempty"`
+	Values       string          `json:"values,omitempty"`
+	ValuesObject map[string]any  `json:"valuesObject,omitempty"`
+	Parameters   []HelmParameter `json:"parameters,omitempty"`
+}
+
+// HelmParameter is a single --set style Helm override
+type HelmParameter struct {
+	Name        string `json:"name"`
+	Value       string `json:"value"`
+	ForceString bool   `json:"forceString,omitempty"`
+}
+
+// KustomizeSource holds the Kustomize overrides of an application source
+type KustomizeSource struct {
+	NamePrefix        string            `json:"namePrefix,omitempty"`
+	NameSuffix        string            `json:"nameSuffix,omitempty"`
+	Namespace         string            `json:"namespace,omitempty"`
+	Images            []string          `json:"images,omitempty"`
+	CommonLabels      map[string]string `json:"commonLabels,omitempty"`
+	CommonAnnotations map[string]string `json:"commonAnnotations,omitempty"`
 }
 
 // ArgocdApplication represents an ArgoCD application
 type ArgocdApplication struct {
 	Metadata struct {
-		Name      string `json:"name"`
-		Namespace string `json:"namespace"`
+		Name              string            `json:"name"`
+		Namespace         string            `json:"namespace"`
+		Labels            map[string]string `json:"labels,omitempty"`
+		Annotations       map[string]string `json:"annotations,omitempty"`
+		CreationTimestamp string            `json:"creationTimestamp,omitempty"`
+		DeletionTimestamp string            `json:"deletionTimestamp,omitempty"`
+		Finalizers        []string          `json:"finalizers,omitempty"`
 	} `json:"metadata"`
 	Spec struct {
-		Project     string `json:"project"`
-		Source      struct {
-			RepoURL        string `json:"repoURL"`
-			Path           string `json:"path"`
-			TargetRevision string `json:"targetRevision"`
-		} `json:"source"`
+		Project string            `json:"project"`
+		Source  ApplicationSource `json:"source"`
+		// Sources is set instead of Source by multi-source applications
+		Sources []ApplicationSource `json:"sources,omitempty"`
+
 		Destination struct {
 			Server    string `json:"server"`
+			Name      string `json:"name,omitempty"`
 			Namespace string `json:"namespace"`
 		} `json:"destination"`
+		SyncPolicy struct {
+			Automated *struct {
+				Prune      bool `json:"prune,omitempty"`
+				SelfHeal   bool `json:"selfHeal,omitempty"`
+				AllowEmpty bool `json:"allowEmpty,omitempty"`
+			} `json:"automated,omitempty"`
+			SyncOptions []string `json:"syncOptions,omitempty"`
+			Retry       *struct {
+				Limit int64 `json:"limit,omitempty"`
+			} `json:"retry,omitempty"`
+		} `json:"syncPolicy,omitempty"`
 	} `json:"spec"`
-	Status struct {
+	// Operation is the requested operation, such as a sync. ArgoCD clears it
+	// once the operation finished.
+	Operation json.RawMessage `json:"operation,omitempty"`
+	Status    struct {
 		Sync struct {
-			Status string `json:"status"`
+			Status   string `json:"status"`
+			Revision string `json:"revision,omitempty"`
 		} `json:"sync"`
 		Health struct {
 			Status string `json:"status"`
 		} `json:"health"`
+		Resources []ResourceStatus  `json:"resources,omitempty"`
+		History   []RevisionHistory `json:"history,omitempty"`
+		Summary   struct {
+			ExternalURLs []string `json:"externalURLs,omitempty"`
+			Images       []string `json:"images,omitempty"`
+		} `json:"summary,omitempty"`
+		Conditions     []ApplicationCondition `json:"conditions,omitempty"`
+		ReconciledAt   string                 `json:"reconciledAt,omitempty"`
+		OperationState struct {
+			Phase      string `json:"phase,omitempty"`
+			Message    string `json:"message,omitempty"`
+			StartedAt  string `json:"startedAt,omitempty"`
+			FinishedAt string `json:"finishedAt,omitempty"`
+			RetryCount int64  `json:"retryCount,omitempty"`
+			Operation  *struct {
+				InitiatedBy struct {
+					Username  string `json:"username,omitempty"`
+					Automated bool   `json:"automated,omitempty"`
+				} `json:"initiatedBy,omitempty"`
+				Sync *struct {
+					Revision string `json:"revision,omitempty"`
+				} `json:"sync,omitempty"`
+			} `json:"operation,omitempty"`
+			SyncResult *struct {
+				Revision  string           `json:"revision,omitempty"`
+				Resources []ResourceResult `json:"resources,omitempty"`
+			} `json:"syncResult,omitempty"`
+		} `json:"operationState,omitempty"`
 	} `json:"status"`
 }
 
@@ -71,7 +206,7 @@ type Cluster struct {
 	Name   string `json:"name"`
 	Server string `json:"server"`
 	Config struct {
-		BearerToken     string            `json:"bearerToken,omitempty"`
+		BearerToken     string `json:"bearerToken,omitempty"`
 		TLSClientConfig struct {
 			Insecure   bool   `json:"insecure,omitempty"`
 			ServerName string `json:"serverName,omitempty"`
@@ -84,8 +219,8 @@ type Cluster struct {
 			RoleARN     string `json:"roleArn,omitempty"`
 		} `json:"awsAuthConfig,omitempty"`
 		ExecProviderConfig struct {
-			Command string   `json:"command,omitempty"`
-			Args    []string `json:"args,omitempty"`
+			Command string            `json:"command,omitempty"`
+			Args    []string          `json:"args,omitempty"`
 			Env     map[string]string `json:"env,omitempty"`
 		} `json:"execProviderConfig,omitempty"`
 	} `json:"config"`
@@ -94,14 +229,21 @@ type Cluster struct {
 		Message    string `json:"message,omitempty"`
 		ModifiedAt string `json:"modifiedAt,omitempty"`
 	} `json:"connectionState,omitempty"`
-	ServerVersion string            `json:"serverVersion,omitempty"`
+	ServerVersion string `json:"serverVersion,omitempty"`
 	Info          struct {
-		ApplicationsCount int `json:"applicationsCount,omitempty"`
-		ServerVersion     string `json:"serverVersion,omitempty"`
+		ApplicationsCount int      `json:"applicationsCount,omitempty"`
+		ServerVersion     string   `json:"serverVersion,omitempty"`
+		APIVersions       []string `json:"apiVersions,omitempty"`
 		CacheInfo         struct {
-			ResourcesCount int `json:"resourcesCount,omitempty"`
-			APIsCount      int `json:"apisCount,omitempty"`
+			ResourcesCount    int    `json:"resourcesCount,omitempty"`
+			APIsCount         int    `json:"apisCount,omitempty"`
+			LastCacheSyncTime string `json:"lastCacheSyncTime,omitempty"`
 		} `json:"cacheInfo,omitempty"`
+		ConnectionState struct {
+			Status     string `json:"status"`
+			Message    string `json:"message,omitempty"`
+			ModifiedAt string `json:"modifiedAt,omitempty"`
+		} `json:"connectionState,omitempty"`
 	} `json:"info,omitempty"`
 }
 
@@ -109,6 +251,7 @@ type Cluster struct {
 type ClusterList struct {
 	Items []Cluster `json:"items"`
 }
+
 // ArgocdApplicationList represents a list of ArgoCD applications
 type ArgocdApplicationList struct {
 	Items []ArgocdApplication `json:"items"`
@@ -121,11 +264,15 @@ type ServerStatus struct {
 	LastRequest  time.Time `json:"last_request"`
 }
 
-// NewMCPServer creates a new ArgoCD MCP server instance
-func NewMCPServer() *MCPServer {
+// NewMCPServer creates a new ArgoCD MCP server instance. It fails when the
+// ArgoCD connection settings are invalid, so problems surface at startup
+// rather than on the first request.
+func NewMCPServer() (*MCPServer, error) {
 	// Load .env file if it exists (non-fatal if it doesn't)
-	if err := godotenv.Load(); err != nil {
-		log.Printf("No .env file found or error loading .env: %v", err)
+	envErr := godotenv.Load()
+	setupLogging()
+	if envErr != nil {
+		slog.Debug("No .env file found or error loading .env", "error", envErr)
 	}
 
 	config := &ServerConfig{
@@ -138,29 +285,41 @@ func NewMCPServer() *MCPServer {
 		StartTime: time.Now(),
 	}
 
-	// Initialize ArgoCD configuration from environment variables
-	argocdCfg := &ArgocdConfig{
-		ServerURL: getEnvWithDefault("ARGOCD_SERVER", "https://localhost:8080"),
-		AuthToken: os.Getenv("ARGOCD_AUTH_TOKEN"),
-		Insecure:  getEnvWithDefault("ARGOCD_INSECURE", "true") == "true",
+	clientCfg, err := loadHTTPClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("invalid ArgoCD configuration: %w", err)
+	}
+	instances, primary, err := loadInstances(clientCfg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ArgoCD configuration: %w", err)
 	}
 
+	mcpServer := &MCPServer{
+		config:    config,
+		status:    status,
+		instances: instances,
+		primary:   primary,
+		history:   newStatusHistory(),
+		pollCfg:   loadPollConfig(),
+		appCache:  newAppListCache(getEnvDuration("ARGOCD_CACHE_TTL", defaultCacheTTL)),
 
-	// Create HTTP client with optional TLS skip
-	httpClient := &http.Client{
-		Timeout: 30 * time.Second,
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: argocdCfg.Insecure,
-			},
-		},
+		requestTimeout: loadRequestTimeout(),
+		callTimeout:    getEnvDuration("ARGOCD_REQUEST_TIMEOUT", defaultCallTimeout),
+		maxRetries:     loadMaxRetries(),
+
+		maxResponseBytes: loadMaxResponseBytes(),
+		compactJSON:      getEnvWithDefault(compactJSONEnv, "false") == "true",
+
+		defaultProject:      os.Getenv("ARGOCD_DEFAULT_PROJECT"),
+		defaultAppNamespace: os.Getenv("ARGOCD_DEFAULT_APP_NAMESPACE"),
 	}
 
-	mcpServer := &MCPServer{
-		config:     config,
-		status:     status,
-		argocdCfg:  argocdCfg,
-		httpClient: httpClient,
+	if getEnvWithDefault(coreModeEnv, "false") == "true" {
+		core, err := loadKubeClient(clientCfg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ArgoCD core mode configuration: %w", err)
+		}
+		mcpServer.core = core
 	}
 
 	// Create the MCP server with implementation info
@@ -172,9 +331,15 @@ func NewMCPServer() *MCPServer {
 	server := mcp.NewServer(impl, nil)
 
 	mcpServer.server = server
+	server.AddReceivingMiddleware(mcpServer.metricsMiddleware, mcpServer.instanceMiddleware, mcpServer.deadlineMiddleware, mcpServer.formatMiddleware)
 	mcpServer.setupHandlers()
 
-	return mcpServer
+	// There is no ArgoCD API server to probe in core mode
+	if getEnvWithDefault(startupCheckEnv, "false") == "true" && mcpServer.core == nil {
+		mcpServer.startupCheck(context.Background())
+	}
+
+	return mcpServer, nil
 }
 
 // setupHandlers configures all the MCP handlers
@@ -189,28 +354,340 @@ func (s *MCPServer) setupHandlers() {
 	// - get_cluster_info
 	// - etc.
 
-	
 	s.server.AddResource(&mcp.Resource{
 		URI:         "argocd://applications",
 		Name:        "ArgoCD Applications",
 		Description: "List of all ArgoCD applications",
 		MIMEType:    "application/json",
 	}, s.handleApplicationsResource)
-	s.server.AddResource(&mcp.Resource{
-		URI:         "argocd://clusters",
-		Name:        "ArgoCD Clusters",
-		Description: "List of all ArgoCD clusters",
+	s.server.AddResourceTemplate(&mcp.ResourceTemplate{
+		URITemplate: "argocd://applications{?view,format,health,sync}",
+		Name:        "ArgoCD Applications View",
+		Description: "List of all ArgoCD applications; view is full (default), summary or names, format is json (default) or ndjson, and health and sync filter by comma-separated statuses (case-insensitive), e.g. health=Degraded,Progressing",
 		MIMEType:    "application/json",
-	}, s.handleClusterResource)
+	}, s.handleApplicationsResource)
+	if s.core == nil {
+		s.server.AddResourceTemplate(&mcp.ResourceTemplate{
+			URITemplate: "argocd://applications/{name}",
+			Name:        "ArgoCD Application",
+			Description: "A single ArgoCD application by name, e.g. argocd://applications/guestbook",
+			MIMEType:    "application/json",
+		}, s.handleApplicationResource)
+		s.server.AddResource(&mcp.Resource{
+			URI:         "argocd://clusters",
+			Name:        "ArgoCD Clusters",
+			Description: "List of all ArgoCD clusters",
+			MIMEType:    "application/json",
+		}, s.handleClusterResource)
+	}
+
+	// Tools
+	addTool(s, &mcp.Tool{
+		Name:        "list_applications",
+		Description: "List applications in summary form, filtered server-side by project, label selector and/or source repository, and client-side by destination cluster and health or sync status (e.g. all Degraded apps)",
+	}, s.handleListApplications)
+	addTool(s, &mcp.Tool{
+		Name:        "get_application",
+		Description: "Get a single ArgoCD application by name, optionally scoped to an app namespace or project",
+	}, s.handleGetApplication)
+	addTool(s, &mcp.Tool{
+		Name:        "get_application_status",
+		Description: "Get just the sync status, health status and current revision of an application; cheap enough to poll",
+	}, s.handleGetApplicationStatus)
+	addTool(s, &mcp.Tool{
+		Name:        "refresh_application",
+		Description: "Make ArgoCD re-read an application's Git source (hard=true also bypasses the manifest cache) and return its updated sync and health status",
+	}, s.handleRefreshApplication)
+	addTool(s, &mcp.Tool{
+		Name:        "validate_application",
+		Description: "Check a create_application spec without creating anything: required fields, project source/destination rules, name conflicts and repository registration",
+	}, s.handleValidateApplication)
+	addTool(s, &mcp.Tool{
+		Name:        "create_application",
+		Description: "Create an ArgoCD application from a Git source path and destination cluster/namespace, optionally with automated sync",
+	}, s.handleCreateApplication)
+	addTool(s, &mcp.Tool{
+		Name:        "list_application_sets",
+		Description: "List ApplicationSets with a summary of their generators (list, git, clusters, matrix, ...), the template's source repositories and the applications each generated",
+	}, s.handleListApplicationSets)
+	addTool(s, &mcp.Tool{
+		Name:        "get_application_history",
+		Description: "List an application's deployment history, newest first, with the history IDs rollback_application accepts",
+	}, s.handleGetApplicationHistory)
+	addTool(s, &mcp.Tool{
+		Name:        "rollback_application",
+		Description: "Roll an application back to a previous deployment from its history, identified by history ID",
+	}, s.handleRollbackApplication)
+	addTool(s, &mcp.Tool{
+		Name:        "terminate_operation",
+		Description: "Terminate the sync or other operation currently running on an application",
+	}, s.handleTerminateOperation)
+	addTool(s, &mcp.Tool{
+		Name:        "run_resource_action",
+		Description: "Run a resource action such as restart on a Deployment, StatefulSet or DaemonSet, or pause/resume on a Rollout, for a resource managed by an application",
+	}, s.handleRunResourceAction)
+	addTool(s, &mcp.Tool{
+		Name:        "get_operation_state",
+		Description: "Get the state of an application's current or last operation (e.g. a sync): whether it is still running, its phase (Running, Succeeded, Failed, Error), message, timing, revision and who started it",
+	}, s.handleGetOperationState)
+	addTool(s, &mcp.Tool{
+		Name:        "delete_application",
+		Description: "Delete an ArgoCD application, optionally keeping its resources in the cluster (cascade=false) or choosing a propagation policy",
+	}, s.handleDeleteApplication)
+	addTool(s, &mcp.Tool{
+		Name:        "delete_application_resource",
+		Description: "Delete a single resource managed by an application, e.g. a stuck Job or orphaned object blocking a sync, optionally forcing removal of its finalizers",
+	}, s.handleDeleteApplicationResource)
+	addTool(s, &mcp.Tool{
+		Name:        "list_clusters",
+		Description: "List registered clusters with their connection status, optionally by name or server URL and filtered by connection status, e.g. [\"Failed\", \"Unknown\"] to find disconnected clusters",
+	}, s.handleListClusters)
+	addTool(s, &mcp.Tool{
+		Name:        "get_cluster",
+		Description: "Get a single registered cluster by its API server URL, including connection state and cache info",
+	}, s.handleGetCluster)
+	addTool(s, &mcp.Tool{
+		Name:        "cluster_summary",
+		Description: "Summarize every registered cluster in one compact list: name, server, connection status, Kubernetes version and application count",
+	}, s.handleClusterSummary)
+	addTool(s, &mcp.Tool{
+		Name:        "list_projects",
+		Description: "List ArgoCD AppProjects with their description, allowed source repos, destinations and policy settings",
+	}, s.handleListProjects)
+	addTool(s, &mcp.Tool{
+		Name:        "create_project",
+		Description: "Create an ArgoCD AppProject with a description, the source repositories it may deploy from and the destination clusters/namespaces it may deploy to",
+	}, s.handleCreateProject)
+	addTool(s, &mcp.Tool{
+		Name:        "list_repositories",
+		Description: "List the repositories registered in ArgoCD with their type and connection state, e.g. to check a repo is connected before creating an application",
+	}, s.handleListRepositories)
+	addTool(s, &mcp.Tool{
+		Name:        "diff_revisions",
+		Description: "Render an application's manifests at two revisions and return the differences between them",
+	}, s.handleDiffRevisions)
+	addTool(s, &mcp.Tool{
+		Name:        "get_application_diff",
+		Description: "Compare each managed resource's live state with its desired state and show a diff for those that are OutOfSync, missing or extra, e.g. to review a pending sync",
+	}, s.handleGetApplicationDiff)
+	addTool(s, &mcp.Tool{
+		Name:        "get_server_status",
+		Description: "Get this MCP server's start time, uptime, request count and last request time",
+	}, s.handleGetServerStatus)
+	addTool(s, &mcp.Tool{
+		Name:        "get_version",
+		Description: "Get the ArgoCD server's version and build information (build date, git commit, bundled kubectl, Helm and Kustomize versions), e.g. to check feature compatibility",
+	}, s.handleGetVersion)
+	addTool(s, &mcp.Tool{
+		Name:        "get_notifications_services",
+		Description: "List the notification services (e.g. slack, email, webhook) configured for ArgoCD notifications, to verify alerting is set up. Returns an empty list when notifications aren't configured",
+	}, s.handleGetNotificationsServices)
+	addTool(s, &mcp.Tool{
+		Name:        "get_cluster_latency",
+		Description: "Report connection timing for each cluster, slowest first, with an optional active probe",
+	}, s.handleClusterLatency)
+	addTool(s, &mcp.Tool{
+		Name:        "get_application_metadata",
+		Description: "Get the metadata of an application: labels, annotations (e.g. owner, cost center or runbook links), creation time and finalizers",
+	}, s.handleGetApplicationMetadata)
+	addTool(s, &mcp.Tool{
+		Name:        "get_application_parameters",
+		Description: "Get the overrides set on an application's sources: Helm release name, value files, values and --set parameters, or Kustomize images, name prefix/suffix and common labels. Empty for plain directory sources",
+	}, s.handleGetApplicationParameters)
+	addTool(s, &mcp.Tool{
+		Name:        "search_applications",
+		Description: "Search applications by free text across name, project, repo URL, path and destination namespace, ranked by relevance",
+	}, s.handleSearchApplications)
+	addTool(s, &mcp.Tool{
+		Name:        "list_destinations",
+		Description: "List every unique destination (cluster and namespace) applications deploy to, with app counts per destination",
+	}, s.handleListDestinations)
+	addTool(s, &mcp.Tool{
+		Name:        "get_controller_status",
+		Description: "Estimate how busy the ArgoCD application controller is: running operations, out-of-sync apps and stale reconciliations",
+	}, s.handleGetControllerStatus)
+	addTool(s, &mcp.Tool{
+		Name:        "annotate_deploy",
+		Description: "Record why a deploy is happening as an annotation on the application, optionally triggering the sync in the same call",
+	}, s.handleAnnotateDeploy)
+	addTool(s, &mcp.Tool{
+		Name:        "patch_application",
+		Description: "Patch an application with a JSON merge patch or JSON patch, for spec or metadata edits no other tool covers (e.g. changing the target revision or Helm values). Returns the patched application",
+	}, s.handlePatchApplication)
+	addTool(s, &mcp.Tool{
+		Name:        "watch_applications",
+		Description: "Watch ArgoCD's live application stream and report sync/health status changes as they happen, instead of polling. Returns after the timeout or once max_changes changes arrived",
+	}, s.handleWatchApplications)
+	addTool(s, &mcp.Tool{
+		Name:        "list_health_transitions",
+		Description: "List applications whose health changed (e.g. Healthy to Degraded) within a recent window, based on statuses observed by this server",
+	}, s.handleListHealthTransitions)
+	addTool(s, &mcp.Tool{
+		Name:        "render_manifests",
+		Description: "Preview what a repository source (repo URL, path, revision, Helm parameters) produces without an existing application; reports clearly when rendering isn't available",
+	}, s.handleRenderManifests)
+	addTool(s, &mcp.Tool{
+		Name:        "find_silent_failures",
+		Description: "Find applications that report Healthy but have SyncError or ComparisonError conditions, meaning new changes can't be reconciled",
+	}, s.handleFindSilentFailures)
+	addTool(s, &mcp.Tool{
+		Name:        "check_sync_readiness",
+		Description: "Pre-flight check before syncing an application: destination cluster reachability, required CRDs, destination namespace and sync options",
+	}, s.handleCheckSyncReadiness)
+	addTool(s, &mcp.Tool{
+		Name:        "sync_and_report",
+		Description: "Sync an application, wait for the operation to finish, and report the outcome: success or failure, duration, resources changed and the first error",
+	}, s.handleSyncAndReport)
+	addTool(s, &mcp.Tool{
+		Name:        "resync_application",
+		Description: "Hard refresh an application, wait for the refresh to complete, then sync it and wait for the outcome. Reports the refresh and sync results, and which step failed if one did",
+	}, s.handleResyncApplication)
+	addTool(s, &mcp.Tool{
+		Name:        "wait_for_sync",
+		Description: "Wait until an application is Synced and Healthy with no sync operation in progress, polling ArgoCD, and return its final status or report that the timeout elapsed. Use after triggering a sync instead of polling from the client",
+	}, s.handleWaitForSync)
+	addTool(s, &mcp.Tool{
+		Name:        "sync_applications",
+		Description: "Trigger a sync of several applications at once, given by name or label selector, e.g. to promote a set of apps together. Returns whether each sync was triggered; it doesn't wait for them to finish",
+	}, s.handleSyncApplications)
+	addTool(s, &mcp.Tool{
+		Name:        "list_apps_on_unreachable_clusters",
+		Description: "List applications that deploy to clusters whose connection state is Failed or Unknown, i.e. the blast radius of a cluster outage",
+	}, s.handleListAppsOnUnreachableClusters)
+	addTool(s, &mcp.Tool{
+		Name:        "get_application_logs",
+		Description: "Get recent container logs from an application's pods, optionally for one pod/container and time window",
+	}, s.handleGetApplicationLogs)
+	addTool(s, &mcp.Tool{
+		Name:        "get_application_resource_tree",
+		Description: "List the live resources an application manages (Deployments, Services, Pods, ...) with their health and owning parent",
+	}, s.handleGetApplicationResourceTree)
+	addTool(s, &mcp.Tool{
+		Name:        "get_resource_count",
+		Description: "Count the resources each application manages (or one named application), largest first, to spot apps that are candidates for splitting",
+	}, s.handleGetResourceCount)
+	addTool(s, &mcp.Tool{
+		Name:        "validate_project_change",
+		Description: "Check which existing applications in an AppProject would stop being permitted under proposed sourceRepos or destinations, before tightening the project",
+	}, s.handleValidateProjectChange)
+	addTool(s, &mcp.Tool{
+		Name:        "get_deploy_frequency",
+		Description: "Rank applications by how often they were deployed within a window, combining ArgoCD's deployment history with syncs triggered through this server",
+	}, s.handleGetDeployFrequency)
+	addTool(s, &mcp.Tool{
+		Name:        "refresh_repo_applications",
+		Description: "Hard refresh only the applications sourced from a Git repository, e.g. after a push to a monorepo",
+	}, s.handleRefreshRepoApplications)
+	addTool(s, &mcp.Tool{
+		Name:        "get_application_urls",
+		Description: "Get the external URLs and container images of a deployed application from its status summary",
+	}, s.handleGetApplicationURLs)
+	addTool(s, &mcp.Tool{
+		Name:        "check_drift_from_git",
+		Description: "Compare an application's live spec in ArgoCD with its definition in Git to catch manual edits made outside the declarative source",
+	}, s.handleCheckDriftFromGit)
+	addTool(s, &mcp.Tool{
+		Name:        "list_accessible_applications",
+		Description: "List the applications the configured ArgoCD identity can view, or perform a given action (sync, update, delete) on, according to RBAC",
+	}, s.handleListAccessibleApplications)
+	addTool(s, &mcp.Tool{
+		Name:        "export_clusters",
+		Description: "Export all registered clusters as declarative ArgoCD cluster Secrets in YAML, with credentials replaced by placeholders, for disaster-recovery records",
+	}, s.handleExportClusters)
+	addTool(s, &mcp.Tool{
+		Name:        "get_reconciliation_error_rate",
+		Description: "Count applications with SyncError or ComparisonError conditions across the instance; a spike usually points at a repo-server or cluster problem",
+	}, s.handleGetReconciliationErrorRate)
+	addTool(s, &mcp.Tool{
+		Name:        "get_application_events",
+		Description: "Get Kubernetes events for an application or one of its resources, newest first, a page at a time; pass the returned continue token to fetch older events",
+	}, s.handleGetApplicationEvents)
+	addTool(s, &mcp.Tool{
+		Name:        "get_replica_status",
+		Description: "Compare desired replicas from an application's manifests with the live and ready pods in its resource tree, flagging workloads whose scale hasn't taken effect",
+	}, s.handleGetReplicaStatus)
+	addTool(s, &mcp.Tool{
+		Name:        "get_effective_sync_policy",
+		Description: "Compute an application's effective sync behavior by merging its syncPolicy with its project's sync windows, source/destination rules and cluster/namespace resource allow and deny lists",
+	}, s.handleGetEffectiveSyncPolicy)
+	addTool(s, &mcp.Tool{
+		Name:        "list_applications_by_creation",
+		Description: "List applications by creation time, newest first by default, optionally only those created in the last N days",
+	}, s.handleListApplicationsByCreation)
+	addTool(s, &mcp.Tool{
+		Name:        "get_sync_waves",
+		Description: "Show an application's resources grouped by sync phase and sync-wave, in the order ArgoCD applies them",
+	}, s.handleGetSyncWaves)
+	addTool(s, &mcp.Tool{
+		Name:        "fleet_conditions",
+		Description: "Summarize application conditions across the whole instance, grouped by type (e.g. ComparisonError, InvalidSpecError) with the affected apps under each",
+	}, s.handleFleetConditions)
+	addTool(s, &mcp.Tool{
+		Name:        "get_revision_status",
+		Description: "Show which commit an application is deployed at and the latest commit of its target revision, with commit messages, and whether it's up to date",
+	}, s.handleGetRevisionStatus)
+	if getEnvWithDefault(refreshAllEnv, "false") == "true" {
+		addTool(s, &mcp.Tool{
+			Name:        "refresh_all_applications",
+			Description: "Hard refresh every application in the instance with bounded concurrency and summarize how many succeeded or failed. Heavy on the repo-server; use after a repo-server config change or cache problem",
+		}, s.handleRefreshAllApplications)
+	}
 }
 
 // Run starts the ArgoCD MCP server
 func (s *MCPServer) Run(ctx context.Context) error {
-	log.Printf("Starting %s v%s", s.config.Name, s.config.Version)
-	log.Printf("Server description: %s", s.config.Description)
+	slog.Info("Starting server", "name", s.config.Name, "version", s.config.Version)
+	slog.Debug("Server description", "description", s.config.Description)
+
+	if s.requestTimeout > 0 {
+		slog.Info("Per-request deadline", "timeout", s.requestTimeout)
+	}
+	if s.callTimeout > 0 {
+		slog.Info("ArgoCD call timeout", "timeout", s.callTimeout)
+	}
+
+	if s.core != nil {
+		// There is no ArgoCD API server to log in to or probe
+		slog.Info("Running in ArgoCD core mode: reading Applications from Kubernetes, only core tools enabled",
+			"kubernetes", s.core.serverURL, "namespace", s.core.namespace)
+		return s.serve(ctx)
+	}
 
-	// Run the server using stdio transport
-	return s.server.Run(ctx, &mcp.StdioTransport{})
+	for _, name := range instanceNames(s.instances) {
+		inst := s.instances[name]
+		slog.Info("ArgoCD server", "name", name, "url", inst.cfg.ServerURL, "primary", inst == s.primary)
+		if !inst.canLogin() {
+			continue
+		}
+		if err := inst.login(ctx, ""); err != nil {
+			// Requests retry the login on demand, so keep serving
+			slog.Warn("Initial ArgoCD login failed", "server", name, "error", err)
+		} else {
+			slog.Info("Logged in to ArgoCD", "server", name, "username", inst.cfg.Username)
+		}
+	}
+
+	s.enableAnonymousMode(ctx)
+
+	return s.serve(ctx)
+}
+
+// serve serves MCP requests on the configured transport until ctx is done
+func (s *MCPServer) serve(ctx context.Context) error {
+	if addr := os.Getenv("METRICS_ADDR"); addr != "" {
+		serveMetrics(ctx, addr)
+	}
+
+	// Each request's context derives from ctx, so cancelling it stops
+	// in-flight handlers too
+	switch transport := getEnvWithDefault("MCP_TRANSPORT", transportStdio); transport {
+	case transportStdio:
+		return s.server.Run(ctx, &mcp.StdioTransport{})
+	case transportHTTP:
+		return s.runHTTP(ctx, getEnvWithDefault("MCP_HTTP_ADDR", defaultHTTPAddr))
+	default:
+		return fmt.Errorf("unknown MCP_TRANSPORT %q: must be %s or %s", transport, transportStdio, transportHTTP)
+	}
 }
 
 // Resource handlers
@@ -218,14 +695,62 @@ func (s *MCPServer) Run(ctx context.Context) error {
 func (s *MCPServer) handleApplicationsResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
 	s.updateRequestStats()
 
+	// format selects a JSON document (default) or NDJSON
+	var query url.Values
+	if uri, err := url.Parse(req.Params.URI); err == nil {
+		query = uri.Query()
+	}
+	view, filter, err := parseApplicationsQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	format := firstNonEmpty(query.Get("format"), "json")
+
+	if format == "ndjson" {
+		// MCP returns a resource in a single message, so the lines are
+		// buffered here; the items themselves are still decoded one at a
+		// time. HTTP clients can stream them from /applications.ndjson.
+		var sb strings.Builder
+		if _, err := s.streamApplications(ctx, &sb, view, filter); err != nil {
+			return nil, fmt.Errorf("failed to get ArgoCD applications: %w", err)
+		}
+		return &mcp.ReadResourceResult{
+			Contents: []*mcp.ResourceContents{
+				{
+					URI:      req.Params.URI,
+					MIMEType: "application/x-ndjson",
+					Text:     sb.String(),
+				},
+			},
+		}, nil
+	} else if format != "json" {
+		return nil, fmt.Errorf("unknown format %q: must be json or ndjson", format)
+	}
+
 	// Make API call to ArgoCD
-	apps, err := s.getArgocdApplications(ctx)
+	apps, err := s.getArgocdApplications(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get ArgoCD applications: %w", err)
 	}
 
+	var payload any = apps
+	switch view {
+	case applicationsViewSummary:
+		summaries := make([]ApplicationSummary, len(apps.Items))
+		for i := range apps.Items {
+			summaries[i] = summarizeApplication(&apps.Items[i])
+		}
+		payload = summaries
+	case applicationsViewNames:
+		names := make([]string, len(apps.Items))
+		for i, app := range apps.Items {
+			names[i] = app.Metadata.Name
+		}
+		payload = names
+	}
+
 	// Convert to JSON
-	appsJSON, err := json.MarshalIndent(apps, "", "  ")
+	appsJSON, err := json.MarshalIndent(payload, "", "  ")
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal applications: %w", err)
 	}
@@ -233,49 +758,178 @@ func (s *MCPServer) handleApplicationsResource(ctx context.Context, req *mcp.Rea
 	return &mcp.ReadResourceResult{
 		Contents: []*mcp.ResourceContents{
 			{
-				URI:      "argocd://applications",
+				URI:      req.Params.URI,
 				MIMEType: "application/json",
 				Text:     string(appsJSON),
 			},
 		},
 	}, nil
 }
-func (s *MCPServer) getArgocdApplications(ctx context.Context) (*ArgocdApplicationList, error) {
-	url := fmt.Sprintf("%s/api/v1/applications", s.argocdCfg.ServerURL)
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+// parseApplicationsQuery reads the query parameters shared by the
+// applications resource and the /applications.ndjson endpoint: view selects
+// how much of each application to return, and health and sync take
+// comma-separated statuses to filter by
+func parseApplicationsQuery(query url.Values) (string, ApplicationFilter, error) {
+	view := firstNonEmpty(query.Get("view"), applicationsViewFull)
+	if view != applicationsViewFull && view != applicationsViewSummary && view != applicationsViewNames {
+		return "", ApplicationFilter{}, fmt.Errorf("unknown view %q: must be one of full, summary, names", view)
 	}
+	return view, ApplicationFilter{
+		Health: splitStatuses(query.Get("health")),
+		Sync:   splitStatuses(query.Get("sync")),
+	}, nil
+}
+
+// ApplicationFilter narrows the application list. Empty fields don't filter.
+type ApplicationFilter struct {
+	Project  string
+	Selector string
+	Repo     string
+	// Cluster matches the destination server URL or cluster name. ArgoCD has
+	// no query parameter for it, so it's applied after the fetch.
+	Cluster string
+	// Health and Sync match any of the listed statuses, ignoring case. Like
+	// Cluster, they're applied after the fetch.
+	Health []string
+	Sync   []string
+}
 
-	// Add authorization header if token is available
-	if s.argocdCfg.AuthToken != "" {
-		req.Header.Set("Authorization", "Bearer "+s.argocdCfg.AuthToken)
+// query returns the filter as ArgoCD list query parameters
+func (f ApplicationFilter) query() url.Values {
+	query := url.Values{}
+	if f.Project != "" {
+		query.Set("projects", f.Project)
 	}
-	req.Header.Set("Content-Type", "application/json")
+	if f.Selector != "" {
+		query.Set("selector", f.Selector)
+	}
+	if f.Repo != "" {
+		query.Set("repo", f.Repo)
+	}
+	return query
+}
 
-	resp, err := s.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
+func (s *MCPServer) getArgocdApplications(ctx context.Context, filter ApplicationFilter) (*ArgocdApplicationList, error) {
+	ctx, cancel := s.callContext(ctx)
+	defer cancel()
+
+	query := filter.query()
+	// Instances can have applications with the same names
+	cacheKey := s.instance(ctx).name + "?" + query.Encode()
+	if items, ok := s.appCache.get(cacheKey); ok {
+		return filter.filterClientSide(&ArgocdApplicationList{Items: items}), nil
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("ArgoCD API returned status %d: %s", resp.StatusCode, string(body))
+	defer observeArgocdCall("list_applications", time.Now())
+
+	if s.core != nil {
+		appList, err := s.core.listApplications(ctx, filter, s.maxResponseBytes)
+		if err != nil {
+			return nil, err
+		}
+		s.history.record(s.instance(ctx).name, appList.Items)
+		s.appCache.put(cacheKey, appList.Items)
+		return filter.filterClientSide(appList), nil
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	body, err := s.doArgocdRequest(ctx, "GET", "/api/v1/applications", query, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, err
 	}
 
 	var appList ArgocdApplicationList
 	if err := json.Unmarshal(body, &appList); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
+	s.history.record(s.instance(ctx).name, appList.Items)
+	s.appCache.put(cacheKey, appList.Items)
+
+	return filter.filterClientSide(&appList), nil
+}
+
+// filterClientSide drops applications not matching the filter fields
+// ArgoCD's list endpoint can't filter on: cluster, health and sync status
+func (f ApplicationFilter) filterClientSide(list *ArgocdApplicationList) *ArgocdApplicationList {
+	if f.Cluster == "" && len(f.Health) == 0 && len(f.Sync) == 0 {
+		return list
+	}
+	matched := list.Items[:0]
+	for i := range list.Items {
+		if f.matchesClientSide(&list.Items[i]) {
+			matched = append(matched, list.Items[i])
+		}
+	}
+	list.Items = matched
+	return list
+}
+
+// matchesClientSide reports whether app passes the filter fields applied
+// after the fetch
+func (f ApplicationFilter) matchesClientSide(app *ArgocdApplication) bool {
+	if f.Cluster != "" && app.Spec.Destination.Server != f.Cluster && app.Spec.Destination.Name != f.Cluster {
+		return false
+	}
+	return matchesStatus(app.Status.Health.Status, f.Health) && matchesStatus(app.Status.Sync.Status, f.Sync)
+}
+
+// matchesStatus reports whether status is one of wanted, ignoring case. An
+// empty wanted list matches every status.
+func matchesStatus(status string, wanted []string) bool {
+	if len(wanted) == 0 {
+		return true
+	}
+	for _, w := range wanted {
+		if strings.EqualFold(strings.TrimSpace(w), status) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitStatuses parses a comma-separated list of statuses from a query parameter
+func splitStatuses(value string) []string {
+	var statuses []string
+	for _, status := range strings.Split(value, ",") {
+		if status = strings.TrimSpace(status); status != "" {
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses
+}
 
-	return &appList, nil
+// applicationResourcePrefix is the URI prefix of single-application resources
+const applicationResourcePrefix = "argocd://applications/"
+
+func (s *MCPServer) handleApplicationResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	s.updateRequestStats()
+
+	name, err := url.PathUnescape(strings.TrimPrefix(req.Params.URI, applicationResourcePrefix))
+	if err != nil || name == "" || strings.Contains(name, "/") {
+		return nil, mcp.ResourceNotFoundError(req.Params.URI)
+	}
+
+	app, err := s.getApplication(ctx, name)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) || errors.Is(err, ErrForbidden) {
+			return nil, mcp.ResourceNotFoundError(req.Params.URI)
+		}
+		return nil, fmt.Errorf("failed to get application %q: %w", name, err)
+	}
+	appJSON, err := json.MarshalIndent(app, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal application: %w", err)
+	}
+
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{
+			{
+				URI:      req.Params.URI,
+				MIMEType: "application/json",
+				Text:     string(appJSON),
+			},
+		},
+	}, nil
 }
 
 func (s *MCPServer) handleClusterResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
@@ -292,41 +946,26 @@ func (s *MCPServer) handleClusterResource(ctx context.Context, req *mcp.ReadReso
 	return &mcp.ReadResourceResult{
 		Contents: []*mcp.ResourceContents{
 			{
-				URI: 	"argocd://clusters",
+				URI:      "argocd://clusters",
 				MIMEType: "application/json",
-				Text:	string(clustersJSON),
+				Text:     string(clustersJSON),
 			},
 		},
 	}, nil
 }
 
 func (s *MCPServer) getClusters(ctx context.Context) (*ClusterList, error) {
-	url := fmt.Sprintf("%s/api/v1/clusters", s.argocdCfg.ServerURL)
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Add authorization header if token is available
-	if s.argocdCfg.AuthToken != "" {
-		req.Header.Set("Authorization", "Bearer "+s.argocdCfg.AuthToken)
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := s.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
-	}
-	defer resp.Body.Close()
+	return s.getClustersWithQuery(ctx, nil)
+}
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("ArgoCD API returned status %d: %s", resp.StatusCode, string(body))
-	}
+// getClustersWithQuery lists clusters, passing query parameters such as
+// name or server through to ArgoCD
+func (s *MCPServer) getClustersWithQuery(ctx context.Context, query url.Values) (*ClusterList, error) {
+	defer observeArgocdCall("list_clusters", time.Now())
 
-	body, err := io.ReadAll(resp.Body)
+	body, err := s.doArgocdRequest(ctx, "GET", "/api/v1/clusters", query, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, err
 	}
 
 	var clusterList ClusterList
@@ -337,12 +976,30 @@ func (s *MCPServer) getClusters(ctx context.Context) (*ClusterList, error) {
 	return &clusterList, nil
 }
 
-
 // Helper functions
 
 func (s *MCPServer) updateRequestStats() {
+	s.statusMu.Lock()
 	s.status.RequestCount++
 	s.status.LastRequest = time.Now()
+	count := s.status.RequestCount
+	s.statusMu.Unlock()
+
+	slog.Debug("Handling request", "request_count", count)
+}
+
+// toolJSON returns v as the indented JSON text content of a tool result
+func toolJSON(v any) (*mcp.CallToolResult, any, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal result: %w", err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: string(data)},
+		},
+	}, nil, nil
 }
 
 func getEnvWithDefault(key, defaultValue string) string {
@@ -350,4 +1007,59 @@ func getEnvWithDefault(key, defaultValue string) string {
 		return value
 	}
 	return defaultValue
-}
\ No newline at end of file
+}
+
+// loadArgocdConfig reads the single ArgoCD server configuration from
+// ARGOCD_SERVER and the related environment variables
+func loadArgocdConfig() *ArgocdConfig {
+	cfg := &ArgocdConfig{
+		ServerURL: getEnvWithDefault("ARGOCD_SERVER", "https://localhost:8080"),
+		AuthToken: os.Getenv("ARGOCD_AUTH_TOKEN"),
+		Insecure:  getEnvWithDefault("ARGOCD_INSECURE", "true") == "true",
+		// Takes precedence over ARGOCD_AUTH_TOKEN
+		AuthTokenFile: os.Getenv("ARGOCD_AUTH_TOKEN_FILE"),
+		// SNI/verification name for when the dialed host differs from the
+		// certificate's name, e.g. when connecting by IP or internal DNS
+		TLSServerName: os.Getenv("ARGOCD_TLS_SERVER_NAME"),
+		CACert:        os.Getenv("ARGOCD_CA_CERT"),
+		ClientCert:    os.Getenv("ARGOCD_CLIENT_CERT"),
+		ClientKey:     os.Getenv("ARGOCD_CLIENT_KEY"),
+	}
+
+	// Username/password login is only used when no token is configured
+	if cfg.AuthToken == "" && cfg.AuthTokenFile == "" {
+		cfg.Username = os.Getenv("ARGOCD_USERNAME")
+		cfg.Password = os.Getenv("ARGOCD_PASSWORD")
+	}
+	return cfg
+}
+
+// getEnvInt parses key as a non-negative integer, falling back to
+// defaultValue when it is unset or invalid
+func getEnvInt(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		slog.Warn("Invalid non-negative integer, using default", "key", key, "value", value, "default", defaultValue)
+		return defaultValue
+	}
+	return n
+}
+
+// getEnvDuration parses key as a Go duration, falling back to defaultValue
+// when it is unset or invalid
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		slog.Warn("Invalid duration, using default", "key", key, "value", value, "default", defaultValue, "error", err)
+		return defaultValue
+	}
+	return d
+}
@@ -0,0 +1,65 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+const (
+	// startupCheckEnv enables probing each ArgoCD server while the MCP
+	// server starts, so misconfiguration shows up in the logs immediately
+	startupCheckEnv = "ARGOCD_STARTUP_CHECK"
+
+	// startupCheckTimeout bounds the probes of each ArgoCD server
+	startupCheckTimeout = 10 * time.Second
+)
+
+// startupCheck logs whether each configured ArgoCD server is reachable and
+// accepts the configured credentials. Failures are only logged: the server
+// still starts, since ArgoCD may come up later.
+func (s *MCPServer) startupCheck(ctx context.Context) {
+	for _, name := range instanceNames(s.instances) {
+		inst := s.instances[name]
+		s.checkInstance(context.WithValue(ctx, instanceKey{}, inst), inst)
+	}
+}
+
+// checkInstance probes a single ArgoCD server for startupCheck
+func (s *MCPServer) checkInstance(ctx context.Context, inst *argocdInstance) {
+	ctx, cancel := context.WithTimeout(ctx, startupCheckTimeout)
+	defer cancel()
+
+	version, err := s.argocdVersion(ctx)
+	if err != nil {
+		slog.Warn("Startup check: ArgoCD is not reachable", "server", inst.name, "url", inst.cfg.ServerURL,
+			"error", err, "remediation", remediationFor(err))
+		return
+	}
+	slog.Info("Startup check: ArgoCD is reachable", "server", inst.name, "url", inst.cfg.ServerURL, "version", version.Version)
+
+	if inst.authToken() == "" {
+		if !inst.canLogin() {
+			slog.Warn("Startup check: no ArgoCD credentials configured; only anonymous access will work", "server", inst.name)
+			return
+		}
+		if err := inst.login(ctx, ""); err != nil {
+			slog.Warn("Startup check: ArgoCD login failed", "server", inst.name, "username", inst.cfg.Username, "error", err)
+			return
+		}
+	}
+
+	// userinfo answers for any token, so it shows whether ArgoCD accepts ours
+	info, err := s.getUserInfo(ctx)
+	switch {
+	case err != nil:
+		slog.Warn("Startup check: ArgoCD rejected the configured credentials", "server", inst.name,
+			"error", err, "remediation", remediationFor(err))
+	case !info.LoggedIn:
+		slog.Warn("Startup check: ArgoCD does not accept the configured token as a valid session", "server", inst.name,
+			"remediation", remediationFor(&APIError{StatusCode: http.StatusUnauthorized}))
+	default:
+		slog.Info("Startup check: authenticated to ArgoCD", "server", inst.name, "username", info.Username)
+	}
+}
@@ -0,0 +1,40 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ServerStatusReport is the result of the get_server_status tool
+type ServerStatusReport struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	ServerStatus
+	Uptime        string `json:"uptime"`
+	UptimeSeconds int64  `json:"uptime_seconds"`
+}
+
+// statusSnapshot returns a copy of the server status that is safe to read
+// while handlers keep updating it
+func (s *MCPServer) statusSnapshot() ServerStatus {
+	s.statusMu.Lock()
+	defer s.statusMu.Unlock()
+	return *s.status
+}
+
+func (s *MCPServer) handleGetServerStatus(ctx context.Context, req *mcp.CallToolRequest, args struct{}) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	status := s.statusSnapshot()
+	uptime := time.Since(status.StartTime)
+
+	return toolJSON(ServerStatusReport{
+		Name:          s.config.Name,
+		Version:       s.config.Version,
+		ServerStatus:  status,
+		Uptime:        uptime.Round(time.Second).String(),
+		UptimeSeconds: int64(uptime.Seconds()),
+	})
+}
@@ -0,0 +1,196 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// streamApplications fetches the application list and writes it to w as
+// NDJSON, one application per line in the given view. The ArgoCD response is
+// decoded item by item, so neither the raw body nor the full list is ever
+// held in memory. Only filter's client-side fields are applied. It returns
+// the number of applications written.
+func (s *MCPServer) streamApplications(ctx context.Context, w io.Writer, view string, filter ApplicationFilter) (int, error) {
+	enc := json.NewEncoder(w)
+
+	// Core mode has no ArgoCD API server to stream from, so the lines are
+	// written from the list read from Kubernetes
+	if s.core != nil {
+		apps, err := s.getArgocdApplications(ctx, filter)
+		if err != nil {
+			return 0, err
+		}
+		for i := range apps.Items {
+			if err := encodeApplication(enc, view, &apps.Items[i]); err != nil {
+				return i, err
+			}
+		}
+		return len(apps.Items), nil
+	}
+
+	url := fmt.Sprintf("%s/api/v1/applications", s.serverURL(ctx))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, newAPIError(resp)
+	}
+
+	dec := json.NewDecoder(resp.Body)
+
+	// Walk the top-level object until the items array starts
+	if err := expectDelim(dec, '{'); err != nil {
+		return 0, err
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return 0, fmt.Errorf("failed to read response: %w", err)
+		}
+		if key, _ := tok.(string); key != "items" {
+			// Skip the value of any other field, such as metadata
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return 0, fmt.Errorf("failed to read response: %w", err)
+			}
+			continue
+		}
+
+		// ArgoCD returns "items": null when there are no applications
+		tok, err = dec.Token()
+		if err != nil {
+			return 0, fmt.Errorf("failed to read response: %w", err)
+		}
+		if tok == nil {
+			return 0, nil
+		}
+		if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+			return 0, fmt.Errorf("unexpected items value in response")
+		}
+
+		count := 0
+		for dec.More() {
+			if err := ctx.Err(); err != nil {
+				return count, err
+			}
+
+			var app ArgocdApplication
+			if err := dec.Decode(&app); err != nil {
+				return count, fmt.Errorf("failed to unmarshal application: %w", err)
+			}
+			s.history.record(s.instance(ctx).name, []ArgocdApplication{app})
+			if !filter.matchesClientSide(&app) {
+				continue
+			}
+
+			if err := encodeApplication(enc, view, &app); err != nil {
+				return count, err
+			}
+			count++
+		}
+		return count, nil
+	}
+
+	return 0, nil
+}
+
+// encodeApplication writes app as one NDJSON line in the given view
+func encodeApplication(enc *json.Encoder, view string, app *ArgocdApplication) error {
+	var line any = app
+	switch view {
+	case applicationsViewSummary:
+		line = summarizeApplication(app)
+	case applicationsViewNames:
+		line = app.Metadata.Name
+	}
+	if err := enc.Encode(line); err != nil {
+		return fmt.Errorf("failed to write application: %w", err)
+	}
+	return nil
+}
+
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != want {
+		return fmt.Errorf("unexpected response: expected %q", want)
+	}
+	return nil
+}
+
+// flushWriter flushes each write to the HTTP client, so NDJSON lines reach
+// it as they are produced rather than when the response ends
+type flushWriter struct {
+	w     http.ResponseWriter
+	rc    *http.ResponseController
+	wrote bool
+}
+
+func (f *flushWriter) Write(p []byte) (int, error) {
+	n, err := f.w.Write(p)
+	f.wrote = true
+	if err != nil {
+		return n, err
+	}
+	return n, f.rc.Flush()
+}
+
+// handleApplicationsStream serves GET /applications.ndjson for the HTTP
+// transport: the application list as NDJSON, written line by line as
+// ArgoCD's response is decoded. It takes the view, health and sync
+// parameters of the applications resource, plus argocd_server.
+func (s *MCPServer) handleApplicationsStream(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	s.updateRequestStats()
+
+	query := r.URL.Query()
+	view, filter, err := parseApplicationsQuery(query)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	ctx := r.Context()
+	if name := query.Get(instanceArg); name != "" {
+		inst, ok := s.instances[name]
+		if !ok {
+			http.Error(w, fmt.Sprintf("unknown %s %q: must be one of %s", instanceArg, name, strings.Join(instanceNames(s.instances), ", ")), http.StatusBadRequest)
+			return
+		}
+		ctx = context.WithValue(ctx, instanceKey{}, inst)
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	fw := &flushWriter{w: w, rc: http.NewResponseController(w)}
+	if _, err := s.streamApplications(ctx, fw, view, filter); err != nil {
+		if !fw.wrote {
+			http.Error(w, "failed to get ArgoCD applications: "+err.Error(), http.StatusBadGateway)
+			return
+		}
+		// The status is already sent; abort the connection so the client
+		// sees a failed transfer rather than a silently truncated list
+		slog.Warn("Streaming applications failed", "error", err)
+		panic(http.ErrAbortHandler)
+	}
+}
@@ -0,0 +1,59 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestApplicationsStream(t *testing.T) {
+	argocd := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":"no session","code":16,"message":"no session information"}`))
+			return
+		}
+		w.Write([]byte(`{"metadata":{},"items":[
+			{"metadata":{"name":"web"},"status":{"health":{"status":"Healthy"}}},
+			{"metadata":{"name":"api"},"status":{"health":{"status":"Degraded"}}},
+			{"metadata":{"name":"db"},"status":{"health":{"status":"Healthy"}}}
+		]}`))
+	}))
+	defer argocd.Close()
+	t.Setenv("ARGOCD_SERVER", argocd.URL)
+	t.Setenv("ARGOCD_SERVERS", "")
+	t.Setenv("ARGOCD_AUTH_TOKEN", "token")
+
+	s, err := NewMCPServer()
+	if err != nil {
+		t.Fatalf("NewMCPServer() error: %v", err)
+	}
+
+	for _, tt := range []struct {
+		query      string
+		wantStatus int
+		wantBody   string
+	}{
+		{"view=names&health=healthy", http.StatusOK, "\"web\"\n\"db\"\n"},
+		{"view=everything", http.StatusBadRequest, "unknown view \"everything\""},
+		{"argocd_server=prod", http.StatusBadRequest, "unknown argocd_server \"prod\""},
+	} {
+		rec := httptest.NewRecorder()
+		s.handleApplicationsStream(rec, httptest.NewRequest("GET", "/applications.ndjson?"+tt.query, nil))
+		if rec.Code != tt.wantStatus || !strings.HasPrefix(rec.Body.String(), tt.wantBody) {
+			t.Errorf("GET ?%s = %d %q, want %d %q", tt.query, rec.Code, rec.Body.String(), tt.wantStatus, tt.wantBody)
+		}
+		if tt.wantStatus == http.StatusOK && !rec.Flushed {
+			t.Errorf("GET ?%s wasn't flushed while streaming", tt.query)
+		}
+	}
+
+	// Failures before the first line still get an error status
+	s.primary.cfg.AuthToken = "wrong"
+	rec := httptest.NewRecorder()
+	s.handleApplicationsStream(rec, httptest.NewRequest("GET", "/applications.ndjson", nil))
+	if rec.Code != http.StatusBadGateway {
+		t.Errorf("GET with a rejected token = %d %q, want %d", rec.Code, rec.Body.String(), http.StatusBadGateway)
+	}
+}
@@ -0,0 +1,380 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// defaultSyncTimeout bounds how long sync tools wait for an operation to finish
+const defaultSyncTimeout = 5 * time.Minute
+
+// Terminal operation phases
+const (
+	phaseSucceeded = "Succeeded"
+	phaseFailed    = "Failed"
+	phaseError     = "Error"
+)
+
+// SyncRequest is the body of an ArgoCD application sync request
+type SyncRequest struct {
+	Name     string `json:"name"`
+	Revision string `json:"revision,omitempty"`
+	Prune    bool   `json:"prune,omitempty"`
+	DryRun   bool   `json:"dryRun,omitempty"`
+}
+
+// ResourceResult is the outcome of syncing a single resource
+type ResourceResult struct {
+	Group     string `json:"group,omitempty"`
+	Version   string `json:"version,omitempty"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Status    string `json:"status,omitempty"`
+	Message   string `json:"message,omitempty"`
+	HookPhase string `json:"hookPhase,omitempty"`
+}
+
+// syncApplication triggers a sync of an application
+func (s *MCPServer) syncApplication(ctx context.Context, sync SyncRequest) (*ArgocdApplication, error) {
+	var app ArgocdApplication
+	if err := s.argocdDo(ctx, "POST", applicationPath(sync.Name)+"/sync", nil, sync, &app); err != nil {
+		return nil, err
+	}
+	if !sync.DryRun {
+		s.history.recordOperation(s.instance(ctx).name, sync.Name)
+	}
+	return &app, nil
+}
+
+// operationFinished reports whether an operation phase is terminal
+func operationFinished(phase string) bool {
+	return phase == phaseSucceeded || phase == phaseFailed || phase == phaseError
+}
+
+// operationInProgress reports whether an application has an operation that
+// is requested or running
+func (app *ArgocdApplication) operationInProgress() bool {
+	if len(app.Operation) > 0 && string(app.Operation) != "null" {
+		return true
+	}
+	phase := app.Status.OperationState.Phase
+	return phase != "" && !operationFinished(phase)
+}
+
+// waitForOperation polls an application until an operation other than the
+// one that started at previousStart finishes. It returns the last observed
+// application along with ctx's error if ctx ends first.
+func (s *MCPServer) waitForOperation(ctx context.Context, name, previousStart, tool string) (*ArgocdApplication, error) {
+	var last *ArgocdApplication
+	err := poll(ctx, s.pollInterval(tool), func(ctx context.Context) (bool, error) {
+		app, err := s.getApplication(ctx, name)
+		if err != nil {
+			return false, err
+		}
+		last = app
+		op := app.Status.OperationState
+		return op.StartedAt != previousStart && operationFinished(op.Phase), nil
+	})
+	return last, err
+}
+
+// SyncAndReportArgs are the arguments for the sync_and_report tool
+type SyncAndReportArgs struct {
+	Name           string `json:"name" jsonschema:"the application name"`
+	Revision       string `json:"revision,omitempty" jsonschema:"revision to sync to (default: the application's target revision)"`
+	Prune          bool   `json:"prune,omitempty" jsonschema:"delete resources that are no longer defined in Git"`
+	TimeoutSeconds int    `json:"timeout_seconds,omitempty" jsonschema:"how long to wait for the sync to finish (default 300, at most 1800)"`
+}
+
+// SyncReport is the concise outcome of a sync
+type SyncReport struct {
+	Application      string  `json:"application"`
+	Outcome          string  `json:"outcome"`
+	Phase            string  `json:"phase,omitempty"`
+	DurationSeconds  float64 `json:"duration_seconds"`
+	Revision         string  `json:"revision,omitempty"`
+	ResourcesChanged int     `json:"resources_changed"`
+	ResourcesTotal   int     `json:"resources_total"`
+	FirstError       string  `json:"first_error,omitempty"`
+	Message          string  `json:"message,omitempty"`
+}
+
+// buildSyncReport summarizes the operation state of app
+func buildSyncReport(app *ArgocdApplication, outcome string, duration time.Duration) *SyncReport {
+	op := app.Status.OperationState
+	report := &SyncReport{
+		Application:     app.Metadata.Name,
+		Outcome:         outcome,
+		Phase:           op.Phase,
+		DurationSeconds: duration.Round(time.Second).Seconds(),
+		Message:         op.Message,
+	}
+
+	// Prefer ArgoCD's own timing when the operation finished
+	if started, err := time.Parse(time.RFC3339, op.StartedAt); err == nil {
+		if finished, err := time.Parse(time.RFC3339, op.FinishedAt); err == nil {
+			report.DurationSeconds = finished.Sub(started).Seconds()
+		}
+	}
+
+	if op.SyncResult != nil {
+		report.Revision = op.SyncResult.Revision
+		report.ResourcesTotal = len(op.SyncResult.Resources)
+		for _, res := range op.SyncResult.Resources {
+			if res.Status == "SyncFailed" && report.FirstError == "" {
+				report.FirstError = fmt.Sprintf("%s/%s: %s", res.Kind, res.Name, res.Message)
+			}
+			if !strings.Contains(res.Message, "unchanged") {
+				report.ResourcesChanged++
+			}
+		}
+	}
+	if report.FirstError == "" && (op.Phase == phaseFailed || op.Phase == phaseError) {
+		report.FirstError = op.Message
+	}
+
+	return report
+}
+
+// Steps of syncAndWait, reported as the step that failed
+const (
+	syncStepSync = "sync"
+	syncStepWait = "wait"
+)
+
+// stoppedWaiting describes why waiting ended before a step finished: the
+// timeout elapsed or the request was cancelled
+func stoppedWaiting(ctx context.Context, timeout time.Duration, step string) (outcome, message string) {
+	if ctx.Err() != nil {
+		return "cancelled", fmt.Sprintf("stopped waiting for the %s because the request was cancelled", step)
+	}
+	return "timeout", fmt.Sprintf("the %s did not finish within %s", step, timeout)
+}
+
+// syncAndWait triggers a sync and waits until it finishes or waitCtx ends.
+// before is the application as it was before the sync, so the result of its
+// previous operation isn't mistaken for this one. A sync that finished,
+// timed out or was cancelled is described by the report; an error is
+// returned, along with the step that failed, only when the sync couldn't be
+// requested or its status couldn't be read.
+func (s *MCPServer) syncAndWait(ctx, waitCtx context.Context, timeout time.Duration, before *ArgocdApplication, sync SyncRequest, tool string) (*SyncReport, string, error) {
+	previousStart := before.Status.OperationState.StartedAt
+	start := time.Now()
+	if _, err := s.syncApplication(waitCtx, sync); err != nil {
+		return nil, syncStepSync, err
+	}
+
+	app, err := s.waitForOperation(waitCtx, sync.Name, previousStart, tool)
+	switch {
+	case err == nil:
+		outcome := "failed"
+		if app.Status.OperationState.Phase == phaseSucceeded {
+			outcome = "succeeded"
+		}
+		return buildSyncReport(app, outcome, time.Since(start)), "", nil
+
+	case waitCtx.Err() != nil:
+		outcome, message := stoppedWaiting(ctx, timeout, "sync")
+		message += "; it may still be running in ArgoCD"
+		// The operation may not even have started yet
+		if app == nil || app.Status.OperationState.StartedAt == previousStart {
+			return &SyncReport{
+				Application:     sync.Name,
+				Outcome:         outcome,
+				DurationSeconds: time.Since(start).Round(time.Second).Seconds(),
+				Message:         message,
+			}, "", nil
+		}
+		report := buildSyncReport(app, outcome, time.Since(start))
+		report.Message = message
+		return report, "", nil
+
+	default:
+		return nil, syncStepWait, err
+	}
+}
+
+func (s *MCPServer) handleSyncAndReport(ctx context.Context, req *mcp.CallToolRequest, args SyncAndReportArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	if args.Name == "" {
+		return toolError(fmt.Errorf("name is required"))
+	}
+	timeout := toolTimeout(args.TimeoutSeconds, defaultSyncTimeout)
+
+	before, err := s.getApplication(ctx, args.Name)
+	if err != nil {
+		return toolError(err)
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	report, _, err := s.syncAndWait(ctx, waitCtx, timeout, before, SyncRequest{
+		Name:     args.Name,
+		Revision: args.Revision,
+		Prune:    args.Prune,
+	}, "sync_and_report")
+	if err != nil {
+		return toolError(err)
+	}
+	return toolJSON(report)
+}
+
+// SyncApplicationsArgs are the arguments for the sync_applications tool
+type SyncApplicationsArgs struct {
+	Names    []string `json:"names,omitempty" jsonschema:"the applications to sync"`
+	Selector string   `json:"selector,omitempty" jsonschema:"sync the applications matching this label selector instead, e.g. team=payments"`
+	Prune    bool     `json:"prune,omitempty" jsonschema:"delete resources that are no longer defined in Git"`
+}
+
+// BatchSyncResult is the outcome of triggering one application's sync
+type BatchSyncResult struct {
+	Triggered bool   `json:"triggered"`
+	Error     string `json:"error,omitempty"`
+}
+
+// BatchSyncReport is the result of the sync_applications tool
+type BatchSyncReport struct {
+	Requested int                        `json:"requested"`
+	Triggered int                        `json:"triggered"`
+	Failed    int                        `json:"failed"`
+	Results   map[string]BatchSyncResult `json:"results"`
+	Note      string                     `json:"note"`
+}
+
+func (s *MCPServer) handleSyncApplications(ctx context.Context, req *mcp.CallToolRequest, args SyncApplicationsArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	if len(args.Names) == 0 && args.Selector == "" {
+		return toolError(fmt.Errorf("names or selector is required"))
+	}
+	if len(args.Names) > 0 && args.Selector != "" {
+		return toolError(fmt.Errorf("set either names or selector, not both"))
+	}
+
+	names := args.Names
+	if args.Selector != "" {
+		apps, err := s.getArgocdApplications(ctx, ApplicationFilter{Selector: args.Selector})
+		if err != nil {
+			return toolError(err)
+		}
+		for _, app := range apps.Items {
+			names = append(names, app.Metadata.Name)
+		}
+	}
+
+	// Each slot is written by a single worker, so no locking is needed
+	errs := make([]error, len(names))
+	started := make([]bool, len(names))
+	forEachConcurrent(ctx, len(names), func(ctx context.Context, i int) {
+		started[i] = true
+		_, errs[i] = s.syncApplication(ctx, SyncRequest{Name: names[i], Prune: args.Prune})
+	})
+
+	report := &BatchSyncReport{
+		Requested: len(names),
+		Results:   make(map[string]BatchSyncResult, len(names)),
+		Note:      "Syncs were triggered, not awaited; use get_application_status or sync_and_report to follow an application.",
+	}
+	for i, name := range names {
+		err := errs[i]
+		if !started[i] {
+			err = fmt.Errorf("not started: %w", ctx.Err())
+		}
+		if err != nil {
+			report.Failed++
+			report.Results[name] = BatchSyncResult{Error: err.Error()}
+			continue
+		}
+		report.Triggered++
+		report.Results[name] = BatchSyncResult{Triggered: true}
+	}
+
+	return toolJSON(report)
+}
+
+// WaitForSyncArgs are the arguments for the wait_for_sync tool
+type WaitForSyncArgs struct {
+	Name           string `json:"name" jsonschema:"the application name"`
+	TimeoutSeconds int    `json:"timeout_seconds,omitempty" jsonschema:"how long to wait for the application to become Synced and Healthy (default 300, at most 1800)"`
+}
+
+// WaitForSyncResult is the result of the wait_for_sync tool
+type WaitForSyncResult struct {
+	Application    string  `json:"application"`
+	Outcome        string  `json:"outcome"`
+	SyncStatus     string  `json:"sync_status"`
+	HealthStatus   string  `json:"health_status"`
+	Revision       string  `json:"revision,omitempty"`
+	OperationPhase string  `json:"operation_phase,omitempty"`
+	WaitedSeconds  float64 `json:"waited_seconds"`
+	Message        string  `json:"message,omitempty"`
+}
+
+func (s *MCPServer) handleWaitForSync(ctx context.Context, req *mcp.CallToolRequest, args WaitForSyncArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	if args.Name == "" {
+		return toolError(fmt.Errorf("name is required"))
+	}
+	timeout := toolTimeout(args.TimeoutSeconds, defaultSyncTimeout)
+
+	// Right after a sync is requested, ArgoCD still shows the previous
+	// operation's result until the new one starts. Remember that operation
+	// so its status isn't mistaken for the outcome of the requested one.
+	before, err := s.getApplication(ctx, args.Name)
+	if err != nil {
+		return toolError(err)
+	}
+	previousStart := before.Status.OperationState.StartedAt
+	phase := before.Status.OperationState.Phase
+	notStarted := before.operationInProgress() && (phase == "" || operationFinished(phase))
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	last := before
+	err = poll(waitCtx, s.pollInterval("wait_for_sync"), func(ctx context.Context) (bool, error) {
+		app, err := s.getApplication(ctx, args.Name)
+		if err != nil {
+			return false, err
+		}
+		last = app
+		if app.operationInProgress() || (notStarted && app.Status.OperationState.StartedAt == previousStart) {
+			return false, nil
+		}
+		return app.Status.Sync.Status == "Synced" && app.Status.Health.Status == "Healthy", nil
+	})
+
+	result := &WaitForSyncResult{
+		Application:   args.Name,
+		Outcome:       "synced",
+		WaitedSeconds: time.Since(start).Round(time.Second).Seconds(),
+	}
+	switch {
+	case err == nil:
+	case waitCtx.Err() != nil && last != nil:
+		result.Outcome = "timeout"
+		result.Message = fmt.Sprintf("the application was not Synced and Healthy within %s", timeout)
+		if ctx.Err() != nil {
+			result.Outcome = "cancelled"
+			result.Message = "stopped waiting because the request was cancelled"
+		}
+	default:
+		return toolError(err)
+	}
+
+	result.SyncStatus = last.Status.Sync.Status
+	result.HealthStatus = last.Status.Health.Status
+	result.Revision = last.Status.Sync.Revision
+	result.OperationPhase = last.Status.OperationState.Phase
+
+	return toolJSON(result)
+}
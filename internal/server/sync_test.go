@@ -0,0 +1,48 @@
+package server
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestToolTimeout(t *testing.T) {
+	tests := []struct {
+		seconds int
+		want    time.Duration
+	}{
+		{0, defaultSyncTimeout},
+		{-5, defaultSyncTimeout},
+		{90, 90 * time.Second},
+		{1800, maxToolTimeout},
+		{1 << 40, maxToolTimeout},
+	}
+	for _, tt := range tests {
+		if got := toolTimeout(tt.seconds, defaultSyncTimeout); got != tt.want {
+			t.Errorf("toolTimeout(%d) = %s, want %s", tt.seconds, got, tt.want)
+		}
+	}
+}
+
+func TestOperationInProgress(t *testing.T) {
+	tests := []struct {
+		name string
+		app  string
+		want bool
+	}{
+		{"never synced", `{}`, false},
+		{"finished", `{"status":{"operationState":{"phase":"Succeeded"}}}`, false},
+		{"requested, previous result shown", `{"operation":{"sync":{}},"status":{"operationState":{"phase":"Succeeded"}}}`, true},
+		{"running", `{"status":{"operationState":{"phase":"Running"}}}`, true},
+		{"terminating", `{"status":{"operationState":{"phase":"Terminating"}}}`, true},
+	}
+	for _, tt := range tests {
+		var app ArgocdApplication
+		if err := json.Unmarshal([]byte(tt.app), &app); err != nil {
+			t.Fatal(err)
+		}
+		if got := app.operationInProgress(); got != tt.want {
+			t.Errorf("%s: operationInProgress() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Transports selectable with MCP_TRANSPORT
+const (
+	transportStdio = "stdio"
+	transportHTTP  = "http"
+)
+
+// defaultHTTPAddr is the bind address for the HTTP transport when MCP_HTTP_ADDR is unset
+const defaultHTTPAddr = "localhost:8000"
+
+// httpShutdownTimeout bounds how long in-flight HTTP sessions get to finish
+// once the server is stopped
+const httpShutdownTimeout = 10 * time.Second
+
+// runHTTP serves MCP over HTTP until ctx is cancelled. Clients connect with
+// server-sent events at /sse, or with the streamable HTTP transport at /mcp.
+// /healthz and /readyz serve liveness and readiness probes, and
+// /applications.ndjson streams the application list.
+func (s *MCPServer) runHTTP(ctx context.Context, addr string) error {
+	getServer := func(*http.Request) *mcp.Server { return s.server }
+
+	mux := http.NewServeMux()
+	mux.Handle("/sse", mcp.NewSSEHandler(getServer))
+	mux.Handle("/mcp", mcp.NewStreamableHTTPHandler(getServer, nil))
+
+	// Probes for running the server as a Kubernetes pod
+	ready := &readinessCheck{check: s.checkArgocdReachable, ttl: readinessCacheTTL}
+	mux.HandleFunc("/healthz", handleHealthz)
+	mux.HandleFunc("/readyz", ready.handleReadyz)
+
+	// MCP returns a resource in one message, so large lists stream here
+	mux.HandleFunc("/applications.ndjson", s.handleApplicationsStream)
+
+	httpServer := &http.Server{
+		Addr:    addr,
+		Handler: mux,
+		// Handlers derive from ctx so stopping the server cancels them too
+		BaseContext: func(net.Listener) context.Context { return ctx },
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		slog.Info("Serving MCP over HTTP (SSE at /sse, streamable HTTP at /mcp)", "addr", addr)
+		errCh <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("http transport failed: %w", err)
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), httpShutdownTimeout)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to shut down http transport: %w", err)
+	}
+	if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
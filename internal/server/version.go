@@ -0,0 +1,78 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// VersionInfo is the build information of an ArgoCD server. ArgoCD doesn't
+// report the Kubernetes version of its clusters here; see cluster_summary.
+type VersionInfo struct {
+	Server           string `json:"server"`
+	Version          string `json:"version"`
+	BuildDate        string `json:"build_date,omitempty"`
+	GitCommit        string `json:"git_commit,omitempty"`
+	GoVersion        string `json:"go_version,omitempty"`
+	Platform         string `json:"platform,omitempty"`
+	KubectlVersion   string `json:"kubectl_version,omitempty"`
+	HelmVersion      string `json:"helm_version,omitempty"`
+	KustomizeVersion string `json:"kustomize_version,omitempty"`
+}
+
+// versionCacheTTL is how long a successful version answer is reused. A
+// long-running server outlives ArgoCD upgrades, so the version can't be kept
+// for good.
+const versionCacheTTL = 10 * time.Minute
+
+// argocdVersion returns the version of the ArgoCD instance selected for ctx,
+// cached for versionCacheTTL
+func (s *MCPServer) argocdVersion(ctx context.Context) (*VersionInfo, error) {
+	inst := s.instance(ctx)
+
+	inst.versionMu.Lock()
+	defer inst.versionMu.Unlock()
+	if inst.version != nil && time.Since(inst.versionAt) < versionCacheTTL {
+		return inst.version, nil
+	}
+
+	var raw struct {
+		Version          string
+		BuildDate        string
+		GitCommit        string
+		GoVersion        string
+		Platform         string
+		KubectlVersion   string
+		HelmVersion      string
+		KustomizeVersion string
+	}
+	if err := s.argocdGet(ctx, "/api/version", nil, &raw); err != nil {
+		return nil, fmt.Errorf("failed to get the version of ArgoCD at %s: %w", inst.cfg.ServerURL, err)
+	}
+
+	inst.version = &VersionInfo{
+		Server:           inst.cfg.ServerURL,
+		Version:          raw.Version,
+		BuildDate:        raw.BuildDate,
+		GitCommit:        raw.GitCommit,
+		GoVersion:        raw.GoVersion,
+		Platform:         raw.Platform,
+		KubectlVersion:   raw.KubectlVersion,
+		HelmVersion:      raw.HelmVersion,
+		KustomizeVersion: raw.KustomizeVersion,
+	}
+	inst.versionAt = time.Now()
+	return inst.version, nil
+}
+
+func (s *MCPServer) handleGetVersion(ctx context.Context, req *mcp.CallToolRequest, args struct{}) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	version, err := s.argocdVersion(ctx)
+	if err != nil {
+		return toolError(err)
+	}
+	return toolJSON(version)
+}
@@ -0,0 +1,211 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// defaultWatchTimeout is how long watch_applications listens by default
+	defaultWatchTimeout = time.Minute
+
+	// defaultWatchMaxChanges ends a watch early once this many changes arrived
+	defaultWatchMaxChanges = 10
+
+	// maxWatchEventBytes bounds a single event on the watch stream; events
+	// carry the whole application
+	maxWatchEventBytes = 8 * 1024 * 1024
+)
+
+// Reasons a watch ended
+const (
+	watchEndMaxChanges   = "max_changes"
+	watchEndTimeout      = "timeout"
+	watchEndStreamClosed = "stream_closed"
+)
+
+// WatchApplicationsArgs are the arguments for the watch_applications tool
+type WatchApplicationsArgs struct {
+	Name           string `json:"name,omitempty" jsonschema:"only watch this application"`
+	Project        string `json:"project,omitempty" jsonschema:"only watch applications in this project"`
+	TimeoutSeconds int    `json:"timeout_seconds,omitempty" jsonschema:"how long to listen for changes (default 60, at most 1800)"`
+	MaxChanges     int    `json:"max_changes,omitempty" jsonschema:"return as soon as this many changes arrived (default 10)"`
+}
+
+// ApplicationStatusChange is a sync or health status change seen on the watch stream
+type ApplicationStatusChange struct {
+	Name                 string `json:"name"`
+	Event                string `json:"event"`
+	SyncStatus           string `json:"sync_status,omitempty"`
+	HealthStatus         string `json:"health_status,omitempty"`
+	PreviousSyncStatus   string `json:"previous_sync_status,omitempty"`
+	PreviousHealthStatus string `json:"previous_health_status,omitempty"`
+	ObservedAt           string `json:"observed_at"`
+}
+
+// WatchReport is the result of the watch_applications tool
+type WatchReport struct {
+	WatchedSeconds float64                   `json:"watched_seconds"`
+	EndedBy        string                    `json:"ended_by"`
+	Changes        []ApplicationStatusChange `json:"changes"`
+}
+
+// watchEvent is one message of ArgoCD's application watch stream
+type watchEvent struct {
+	Result *struct {
+		Type        string            `json:"type"`
+		Application ArgocdApplication `json:"application"`
+	} `json:"result"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// watchApplications follows ArgoCD's application watch stream and calls
+// onChange whenever an application's sync or health status changes or it is
+// deleted. The stream first replays every application, which only sets the
+// baseline. It returns nil when the stream closes and ctx's error when ctx is
+// done; onChange returning false stops the watch early.
+func (s *MCPServer) watchApplications(ctx context.Context, query url.Values, onChange func(ApplicationStatusChange) bool) error {
+	reqURL := s.serverURL(ctx) + "/api/v1/stream/applications"
+	if len(query) > 0 {
+		reqURL += "?" + query.Encode()
+	}
+
+	// The stream stays open for as long as ctx allows
+	req, err := http.NewRequestWithContext(withStreaming(ctx), "GET", reqURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return newAPIError(resp)
+	}
+
+	type status struct{ sync, health string }
+	seen := map[string]status{}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), maxWatchEventBytes)
+	for scanner.Scan() {
+		var event watchEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return fmt.Errorf("failed to decode watch event: %w", err)
+		}
+		if event.Error != nil {
+			return fmt.Errorf("watch stream failed: %s", event.Error.Message)
+		}
+		if event.Result == nil {
+			continue
+		}
+
+		app := &event.Result.Application
+		name := app.Metadata.Name
+		current := status{app.Status.Sync.Status, app.Status.Health.Status}
+		previous, known := seen[name]
+
+		change := ApplicationStatusChange{
+			Name:                 name,
+			Event:                event.Result.Type,
+			SyncStatus:           current.sync,
+			HealthStatus:         current.health,
+			PreviousSyncStatus:   previous.sync,
+			PreviousHealthStatus: previous.health,
+			ObservedAt:           time.Now().UTC().Format(time.RFC3339),
+		}
+
+		if event.Result.Type == "DELETED" {
+			delete(seen, name)
+			if !onChange(change) {
+				return nil
+			}
+			continue
+		}
+
+		seen[name] = current
+		if !known || previous == current {
+			continue
+		}
+		if !onChange(change) {
+			return nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("failed to read watch stream: %w", err)
+	}
+	return nil
+}
+
+func (s *MCPServer) handleWatchApplications(ctx context.Context, req *mcp.CallToolRequest, args WatchApplicationsArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	timeout := toolTimeout(args.TimeoutSeconds, defaultWatchTimeout)
+	maxChanges := args.MaxChanges
+	if maxChanges <= 0 {
+		maxChanges = defaultWatchMaxChanges
+	}
+
+	query := url.Values{}
+	if args.Name != "" {
+		query.Set("name", args.Name)
+	}
+	if args.Project != "" {
+		query.Set("projects", args.Project)
+	}
+
+	watchCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Clients that pass a progress token get each change as it happens
+	progressToken := req.Params.GetProgressToken()
+
+	report := &WatchReport{Changes: []ApplicationStatusChange{}}
+	start := time.Now()
+	err := s.watchApplications(watchCtx, query, func(change ApplicationStatusChange) bool {
+		report.Changes = append(report.Changes, change)
+		if progressToken != nil {
+			notifyErr := req.Session.NotifyProgress(ctx, &mcp.ProgressNotificationParams{
+				ProgressToken: progressToken,
+				Progress:      float64(len(report.Changes)),
+				Total:         float64(maxChanges),
+				Message:       fmt.Sprintf("%s %s: sync %s, health %s", change.Name, change.Event, change.SyncStatus, change.HealthStatus),
+			})
+			if notifyErr != nil {
+				slog.Debug("Failed to send watch progress notification", "error", notifyErr)
+			}
+		}
+		return len(report.Changes) < maxChanges
+	})
+	report.WatchedSeconds = time.Since(start).Round(time.Millisecond).Seconds()
+
+	switch {
+	case len(report.Changes) >= maxChanges:
+		report.EndedBy = watchEndMaxChanges
+	case errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil:
+		report.EndedBy = watchEndTimeout
+	case err != nil:
+		return toolError(err)
+	default:
+		report.EndedBy = watchEndStreamClosed
+	}
+
+	return toolJSON(report)
+}
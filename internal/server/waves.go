@@ -0,0 +1,213 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Annotations that control when ArgoCD applies a resource during a sync
+const (
+	syncWaveAnnotation       = "argocd.argoproj.io/sync-wave"
+	hookAnnotation           = "argocd.argoproj.io/hook"
+	helmHookAnnotation       = "helm.sh/hook"
+	helmHookWeightAnnotation = "helm.sh/hook-weight"
+)
+
+// syncPhases lists sync phases in the order ArgoCD runs them
+var syncPhases = []string{"PreSync", "Sync", "PostSync", "SyncFail"}
+
+// helmHookPhases maps Helm hooks onto the ArgoCD phase they run in
+var helmHookPhases = map[string]string{
+	"pre-install":  "PreSync",
+	"pre-upgrade":  "PreSync",
+	"post-install": "PostSync",
+	"post-upgrade": "PostSync",
+}
+
+// kindOrder is the order ArgoCD applies kinds within the same phase and wave,
+// following gitops-engine's sync task ordering. Unlisted kinds go last.
+var kindOrder = func() map[string]int {
+	kinds := []string{
+		"Namespace", "NetworkPolicy", "ResourceQuota", "LimitRange", "PodSecurityPolicy",
+		"PodDisruptionBudget", "ServiceAccount", "Secret", "SecretList", "ConfigMap",
+		"StorageClass", "PersistentVolume", "PersistentVolumeClaim", "CustomResourceDefinition",
+		"ClusterRole", "ClusterRoleList", "ClusterRoleBinding", "ClusterRoleBindingList",
+		"Role", "RoleList", "RoleBinding", "RoleBindingList", "Service", "DaemonSet", "Pod",
+		"ReplicationController", "ReplicaSet", "Deployment", "HorizontalPodAutoscaler",
+		"StatefulSet", "Job", "CronJob", "Ingress", "APIService",
+	}
+	order := make(map[string]int, len(kinds))
+	for i, kind := range kinds {
+		order[kind] = i
+	}
+	return order
+}()
+
+// WaveResource is a resource with its position in the sync order
+type WaveResource struct {
+	Order     int    `json:"order"`
+	Phase     string `json:"phase"`
+	Wave      int    `json:"wave"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Hook      bool   `json:"hook,omitempty"`
+	Warning   string `json:"warning,omitempty"`
+}
+
+// SyncWave is a group of resources ArgoCD applies together
+type SyncWave struct {
+	Phase     string   `json:"phase"`
+	Wave      int      `json:"wave"`
+	Resources []string `json:"resources"`
+}
+
+// SyncWavesReport is the result of the get_sync_waves tool
+type SyncWavesReport struct {
+	Application string         `json:"application"`
+	Waves       []SyncWave     `json:"waves"`
+	Resources   []WaveResource `json:"resources"`
+}
+
+// syncPosition reads the phase and wave of a rendered resource from its
+// annotations. A wave that doesn't parse is reported and treated as 0, as
+// ArgoCD does.
+func syncPosition(annotations map[string]any) (phase string, wave int, hook bool, warning string) {
+	phase = "Sync"
+	if hooks, _ := annotations[hookAnnotation].(string); hooks != "" {
+		// A resource may be a hook for several phases; it runs first in the earliest
+		phase, hook = earliestPhase(strings.Split(hooks, ",")), true
+		if phase == "Skip" {
+			return phase, 0, hook, "not applied: marked with the Skip hook"
+		}
+	} else if hooks, _ := annotations[helmHookAnnotation].(string); hooks != "" {
+		for _, h := range strings.Split(hooks, ",") {
+			if p, ok := helmHookPhases[strings.TrimSpace(h)]; ok {
+				phase, hook = p, true
+				break
+			}
+		}
+	}
+
+	raw, _ := annotations[syncWaveAnnotation].(string)
+	if raw == "" && hook {
+		raw, _ = annotations[helmHookWeightAnnotation].(string)
+	}
+	if raw != "" {
+		w, err := strconv.Atoi(strings.TrimSpace(raw))
+		if err != nil {
+			warning = fmt.Sprintf("invalid sync wave %q, treated as 0", raw)
+		}
+		wave = w
+	}
+	return phase, wave, hook, warning
+}
+
+// earliestPhase returns whichever of phases ArgoCD runs first
+func earliestPhase(phases []string) string {
+	best := ""
+	for _, p := range phases {
+		p = strings.TrimSpace(p)
+		if phaseIndex(p) < phaseIndex(best) || best == "" {
+			best = p
+		}
+	}
+	return best
+}
+
+func phaseIndex(phase string) int {
+	for i, p := range syncPhases {
+		if p == phase {
+			return i
+		}
+	}
+	return len(syncPhases)
+}
+
+func kindIndex(kind string) int {
+	if i, ok := kindOrder[kind]; ok {
+		return i
+	}
+	return len(kindOrder)
+}
+
+func (s *MCPServer) handleGetSyncWaves(ctx context.Context, req *mcp.CallToolRequest, args ApplicationNameArgs) (*mcp.CallToolResult, any, error) {
+	s.updateRequestStats()
+
+	if args.Name == "" {
+		return toolError(fmt.Errorf("name is required"))
+	}
+
+	manifests, err := s.getApplicationManifests(ctx, args.Name, "")
+	if err != nil {
+		return toolError(err)
+	}
+	objects, err := parseManifests(manifests.Manifests)
+	if err != nil {
+		return toolError(err)
+	}
+
+	resources := make([]WaveResource, 0, len(objects))
+	for _, obj := range objects {
+		kind, _ := obj["kind"].(string)
+		metadata, _ := obj["metadata"].(map[string]any)
+		name, _ := metadata["name"].(string)
+		namespace, _ := metadata["namespace"].(string)
+		annotations, _ := metadata["annotations"].(map[string]any)
+
+		phase, wave, hook, warning := syncPosition(annotations)
+		resources = append(resources, WaveResource{
+			Phase:     phase,
+			Wave:      wave,
+			Kind:      kind,
+			Namespace: namespace,
+			Name:      name,
+			Hook:      hook,
+			Warning:   warning,
+		})
+	}
+
+	sort.Slice(resources, func(i, j int) bool {
+		a, b := resources[i], resources[j]
+		if pa, pb := phaseIndex(a.Phase), phaseIndex(b.Phase); pa != pb {
+			return pa < pb
+		}
+		if a.Wave != b.Wave {
+			return a.Wave < b.Wave
+		}
+		if ka, kb := kindIndex(a.Kind), kindIndex(b.Kind); ka != kb {
+			return ka < kb
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Namespace+"/"+a.Name < b.Namespace+"/"+b.Name
+	})
+
+	report := &SyncWavesReport{
+		Application: args.Name,
+		Waves:       []SyncWave{},
+		Resources:   resources,
+	}
+	for i := range resources {
+		r := &resources[i]
+		r.Order = i + 1
+		id := r.Kind + "/" + r.Name
+		if r.Namespace != "" {
+			id = r.Kind + "/" + r.Namespace + "/" + r.Name
+		}
+		last := len(report.Waves) - 1
+		if last < 0 || report.Waves[last].Phase != r.Phase || report.Waves[last].Wave != r.Wave {
+			report.Waves = append(report.Waves, SyncWave{Phase: r.Phase, Wave: r.Wave})
+			last++
+		}
+		report.Waves[last].Resources = append(report.Waves[last].Resources, id)
+	}
+
+	return toolJSON(report)
+}